@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"helm.sh/helm/v3/pkg/kube"
+)
+
+// cancelledTTL bounds how long a cancellation for an unknown id is kept
+// around, waiting for the operation to start.
+const cancelledTTL = time.Minute
+
+// operations tracks in-flight requests by the id assigned on the rust side,
+// so dropping the rust future can cancel the go context.
+var operations = struct {
+	sync.Mutex
+	running   map[uint64]context.CancelFunc
+	cancelled map[uint64]time.Time
+}{
+	running:   map[uint64]context.CancelFunc{},
+	cancelled: map[uint64]time.Time{},
+}
+
+// operationContext returns a context that is cancelled when cancel is called
+// with the same id. The returned func must be called once the operation is done.
+func operationContext(id uint64) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if id == 0 {
+		return ctx, cancel
+	}
+
+	operations.Lock()
+	defer operations.Unlock()
+
+	// The cancellation can race the goroutine starting the operation
+	if _, ok := operations.cancelled[id]; ok {
+		delete(operations.cancelled, id)
+		cancel()
+
+		return ctx, cancel
+	}
+
+	operations.running[id] = cancel
+
+	return ctx, func() {
+		operations.Lock()
+		delete(operations.running, id)
+		operations.Unlock()
+
+		cancel()
+	}
+}
+
+// cancelOperation cancels the context of the operation with the given id, or
+// remembers the id if the operation has not started yet.
+func cancelOperation(id uint64) {
+	operations.Lock()
+	defer operations.Unlock()
+
+	if cancel, ok := operations.running[id]; ok {
+		delete(operations.running, id)
+		cancel()
+
+		return
+	}
+
+	now := time.Now()
+	for cancelledID, at := range operations.cancelled {
+		if now.Sub(at) > cancelledTTL {
+			delete(operations.cancelled, cancelledID)
+		}
+	}
+	operations.cancelled[id] = now
+}
+
+// contextKubeClient stops the waits of the kube client once the context is
+// done, for the actions that don't take a context. Like helm does for
+// installs, the interrupted wait keeps running in the background until its
+// timeout.
+type contextKubeClient struct {
+	kube.Interface
+	ctx context.Context
+}
+
+func withContextKubeClient(ctx context.Context, kubeClient kube.Interface) kube.Interface {
+	return contextKubeClient{Interface: kubeClient, ctx: ctx}
+}
+
+func (c contextKubeClient) Wait(resources kube.ResourceList, timeout time.Duration) error {
+	return c.wait(func() error { return c.Interface.Wait(resources, timeout) })
+}
+
+func (c contextKubeClient) WaitWithJobs(resources kube.ResourceList, timeout time.Duration) error {
+	return c.wait(func() error { return c.Interface.WaitWithJobs(resources, timeout) })
+}
+
+func (c contextKubeClient) WatchUntilReady(resources kube.ResourceList, timeout time.Duration) error {
+	return c.wait(func() error { return c.Interface.WatchUntilReady(resources, timeout) })
+}
+
+// WaitForDelete keeps the hook delete policies waiting like with the wrapped
+// client.
+func (c contextKubeClient) WaitForDelete(resources kube.ResourceList, timeout time.Duration) error {
+	ext, ok := c.Interface.(kube.InterfaceExt)
+	if !ok {
+		return nil
+	}
+
+	return c.wait(func() error { return ext.WaitForDelete(resources, timeout) })
+}
+
+func (c contextKubeClient) wait(wait func() error) error {
+	if err := c.ctx.Err(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- wait() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-c.ctx.Done():
+		return c.ctx.Err()
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"cmp"
+	"log"
+	"os"
+	"path/filepath"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/registry"
+	"k8s.io/client-go/util/homedir"
+)
+
+// chartSource holds the options used to fetch a chart from a repository or
+// an OCI registry, like the flags shared by `helm install` and `helm upgrade`.
+type chartSource struct {
+	CertFile string
+	KeyFile  string
+	CaFile   string
+	Insecure bool
+	// PlainHTTP talks to the OCI registry over http
+	PlainHTTP bool
+	Username  string
+	Password  string
+	// PassCredentialsAll sends the credentials to every domain, not only
+	// the repository one
+	PassCredentialsAll bool
+	// RepoURL looks the chart up in this repository instead of the local
+	// repositories
+	RepoURL string
+	// Keyring defaults to the GnuPG public keyring of the user
+	Keyring string
+	Verify  bool
+}
+
+// apply sets the chart source on the chart path options of an action.
+func (s chartSource) apply(opts *action.ChartPathOptions) {
+	opts.CertFile = s.CertFile
+	opts.KeyFile = s.KeyFile
+	opts.CaFile = s.CaFile
+	opts.InsecureSkipTLSverify = s.Insecure
+	opts.PlainHTTP = s.PlainHTTP
+	opts.Username = s.Username
+	opts.Password = s.Password
+	opts.PassCredentialsAll = s.PassCredentialsAll
+	opts.RepoURL = s.RepoURL
+	opts.Keyring = cmp.Or(s.Keyring, defaultKeyring())
+	opts.Verify = s.Verify
+}
+
+// registryClient creates the registry client used to pull charts from OCI
+// registries with the TLS options and credentials of the source.
+func (s chartSource) registryClient(settings *helmSettings, logger *log.Logger) (*registry.Client, error) {
+	return newRegistryClientTLS(
+		settings,
+		logger,
+		s.CertFile,
+		s.KeyFile,
+		s.CaFile,
+		s.Insecure,
+		s.PlainHTTP,
+		s.Username,
+		s.Password)
+}
+
+// defaultKeyring returns the keyring helm uses when none is given.
+func defaultKeyring() string {
+	if gnupgHome := os.Getenv("GNUPGHOME"); gnupgHome != "" {
+		return filepath.Join(gnupgHome, "pubring.gpg")
+	}
+
+	return filepath.Join(homedir.HomeDir(), ".gnupg", "pubring.gpg")
+}
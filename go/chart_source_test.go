@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+// chartRepository serves the test chart from a repository requiring basic
+// auth, over TLS when caFile is set. It returns the repository URL.
+func chartRepository(t *testing.T, caFile string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	chart, err := loader.Load(testChart)
+	if err != nil {
+		t.Fatalf("failed to load the test chart: %v", err)
+	}
+	if _, err := chartutil.Save(chart, dir); err != nil {
+		t.Fatalf("failed to package the test chart: %v", err)
+	}
+	index, err := repo.IndexDirectory(dir, "")
+	if err != nil {
+		t.Fatalf("failed to index the repository: %v", err)
+	}
+	if err := index.WriteFile(filepath.Join(dir, "index.yaml"), 0o644); err != nil {
+		t.Fatalf("failed to write the repository index: %v", err)
+	}
+
+	files := http.FileServer(http.Dir(dir))
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+		files.ServeHTTP(w, r)
+	})
+
+	if caFile == "" {
+		server := httptest.NewServer(handler)
+		t.Cleanup(server.Close)
+
+		return server.URL
+	}
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, ca, 0o600); err != nil {
+		t.Fatalf("failed to write the repository CA: %v", err)
+	}
+
+	return server.URL
+}
+
+func TestInstallChartSource(t *testing.T) {
+	url := chartRepository(t, "")
+
+	req := InstallRequest{
+		release_name: "podinfo",
+		chart:        "test",
+		ns:           "install-chart-source",
+		repo_url:     url,
+		env:          testEnv(fakeKubePrinting),
+	}
+
+	resp := Helm{}.install(&req)
+	if resp.err_kind != errKindAuth {
+		t.Errorf("expected error kind %q without credentials, got %q: %v", errKindAuth, resp.err_kind, resp.err)
+	}
+
+	req.username = "user"
+	req.password = "secret"
+	resp = Helm{}.install(&req)
+	if len(resp.err) > 0 {
+		t.Fatalf("install failed: %v", resp.err)
+	}
+	if len(resp.release) != 1 || resp.release[0].chart_name != "test" {
+		t.Errorf("unexpected release %+v", resp.release)
+	}
+}
+
+func TestUpgradeChartSourceTLS(t *testing.T) {
+	installTestRelease(t, "upgrade-chart-source", "podinfo")
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	url := chartRepository(t, caFile)
+
+	req := UpgradeRequest{
+		release_name: "podinfo",
+		chart:        "test",
+		ns:           "upgrade-chart-source",
+		repo_url:     url,
+		username:     "user",
+		password:     "secret",
+		env:          testEnv(fakeKubePrinting),
+	}
+
+	resp := Helm{}.upgrade(&req)
+	if len(resp.err) == 0 {
+		t.Error("expected an upgrade without the repository CA to fail")
+	}
+
+	req.ca_file = caFile
+	resp = Helm{}.upgrade(&req)
+	if len(resp.err) > 0 {
+		t.Fatalf("upgrade failed: %v", resp.err)
+	}
+	if resp.revision != 2 {
+		t.Errorf("expected the upgrade to revision 2, got %d", resp.revision)
+	}
+
+	req.ca_file = ""
+	req.insecure = true
+	resp = Helm{}.upgrade(&req)
+	if len(resp.err) > 0 {
+		t.Errorf("expected an insecure upgrade to succeed: %v", resp.err)
+	}
+}
+
+func TestTemplateChartSource(t *testing.T) {
+	req := TemplateRequest{
+		chart:    "test",
+		ns:       "template-chart-source",
+		repo_url: chartRepository(t, ""),
+	}
+
+	resp := Helm{}.template(&req)
+	if resp.err_kind != errKindAuth {
+		t.Errorf("expected error kind %q without credentials, got %q: %v", errKindAuth, resp.err_kind, resp.err)
+	}
+
+	req.username = "user"
+	req.password = "secret"
+	resp = Helm{}.template(&req)
+	if len(resp.err) > 0 {
+		t.Fatalf("template failed: %v", resp.err)
+	}
+	if !strings.Contains(resp.manifest, "name: release-name\n") {
+		t.Errorf("manifest is missing the configmap:\n%s", resp.manifest)
+	}
+}
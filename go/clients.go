@@ -0,0 +1,216 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/restmapper"
+)
+
+// clientKey identifies the settings the kube clients are built from.
+type clientKey struct {
+	kubeConfig    string
+	kubeContext   string
+	kubeToken     string
+	kubeCaFile    string
+	apiServer     string
+	asUser        string
+	asGroups      string
+	tlsServerName string
+	insecure      bool
+	burstLimit    int
+	qps           float32
+	// credentials is a digest of the inline credentials, so they are not
+	// kept around in the keys
+	credentials string
+	namespace   string
+}
+
+func clientKeyOf(settings *helmSettings) clientKey {
+	credentials := settings.Credentials
+
+	digest := sha256.New()
+	for _, data := range [][]byte{credentials.Config, credentials.CAData, credentials.CertData, credentials.KeyData} {
+		fmt.Fprintf(digest, "%d:", len(data))
+		digest.Write(data)
+	}
+	fmt.Fprintf(digest, "%t", credentials.InCluster)
+
+	return clientKey{
+		kubeConfig:    settings.KubeConfig,
+		kubeContext:   settings.KubeContext,
+		kubeToken:     settings.KubeToken,
+		kubeCaFile:    settings.KubeCaFile,
+		apiServer:     settings.KubeAPIServer,
+		asUser:        settings.KubeAsUser,
+		asGroups:      strings.Join(settings.KubeAsGroups, ","),
+		tlsServerName: settings.KubeTLSServerName,
+		insecure:      settings.KubeInsecureSkipTLSVerify,
+		burstLimit:    settings.BurstLimit,
+		qps:           settings.QPS,
+		credentials:   hex.EncodeToString(digest.Sum(nil)),
+		namespace:     settings.namespace,
+	}
+}
+
+type cachedClients struct {
+	getter  genericclioptions.RESTClientGetter
+	expires time.Time
+}
+
+// clientCache keeps the rest client getters, with their discovery cache and
+// rest mapper, across calls. It is opt-in through the ttl of the settings,
+// since a cached getter keeps using the kubeconfig file it read until it
+// expires or is invalidated. Only the getters are cached: the action
+// configuration, the storage driver and the registry client are still built
+// per call, they carry the logger and the options of the request.
+var clientCache = struct {
+	sync.Mutex
+	byKey map[clientKey]*cachedClients
+}{
+	byKey: map[clientKey]*cachedClients{},
+}
+
+// restClientGetter returns the cached getter for the settings, creating it
+// when there is none or it expired. A zero ttl bypasses the cache.
+func restClientGetter(settings *helmSettings) genericclioptions.RESTClientGetter {
+	if settings.ClientCacheTTL <= 0 {
+		return settings.RESTClientGetter()
+	}
+
+	key := clientKeyOf(settings)
+
+	clientCache.Lock()
+	defer clientCache.Unlock()
+
+	now := time.Now()
+	for k, clients := range clientCache.byKey {
+		if now.After(clients.expires) {
+			delete(clientCache.byKey, k)
+		}
+	}
+
+	clients, ok := clientCache.byKey[key]
+	if !ok {
+		getter := settings.RESTClientGetter()
+		// The inline getter memoizes its clients already
+		if _, inline := getter.(*inlineRESTClientGetter); !inline {
+			getter = &memoizedRESTClientGetter{RESTClientGetter: getter}
+		}
+
+		clients = &cachedClients{
+			getter:  getter,
+			expires: now.Add(settings.ClientCacheTTL),
+		}
+		clientCache.byKey[key] = clients
+	}
+
+	return clients.getter
+}
+
+// memoizedRESTClientGetter keeps the discovery client and the rest mapper of
+// the getter it wraps, the helm getter builds new ones on every call.
+type memoizedRESTClientGetter struct {
+	genericclioptions.RESTClientGetter
+
+	mu        sync.Mutex
+	discovery discovery.CachedDiscoveryInterface
+	mapper    meta.RESTMapper
+}
+
+func (g *memoizedRESTClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.discoveryClient()
+}
+
+func (g *memoizedRESTClientGetter) discoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	if g.discovery != nil {
+		return g.discovery, nil
+	}
+
+	client, err := g.RESTClientGetter.ToDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	g.discovery = client
+
+	return g.discovery, nil
+}
+
+func (g *memoizedRESTClientGetter) ToRESTMapper() (meta.RESTMapper, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.mapper != nil {
+		return g.mapper, nil
+	}
+
+	client, err := g.discoveryClient()
+	if err != nil {
+		return nil, err
+	}
+
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(client)
+	g.mapper = restmapper.NewShortcutExpander(mapper, client, nil)
+
+	return g.mapper, nil
+}
+
+// invalidateClients forgets the cached clients of the settings, or all of
+// them.
+func invalidateClients(settings *helmSettings, all bool) {
+	clientCache.Lock()
+	defer clientCache.Unlock()
+
+	if all {
+		clear(clientCache.byKey)
+
+		return
+	}
+
+	delete(clientCache.byKey, clientKeyOf(settings))
+}
+
+// warmClients fills the cache for the settings, and loads the discovery and
+// the rest mapper, so the first call on the cluster doesn't pay for them.
+func warmClients(logger *log.Logger, settings *helmSettings) error {
+	if settings.ClientCacheTTL <= 0 {
+		return errors.New("failed to warm the clients: the client cache is disabled, set a client cache ttl")
+	}
+
+	getter := restClientGetter(settings)
+
+	discovery, err := getter.ToDiscoveryClient()
+	if err != nil {
+		return fmt.Errorf("failed to create the discovery client: %w", err)
+	}
+	if _, _, err := discovery.ServerGroupsAndResources(); err != nil {
+		invalidateClients(settings, false)
+
+		return fmt.Errorf("failed to discover the api resources: %w", err)
+	}
+
+	mapper, err := getter.ToRESTMapper()
+	if err != nil {
+		return fmt.Errorf("failed to create the rest mapper: %w", err)
+	}
+	if _, err := mapper.RESTMapping(schema.GroupKind{Kind: "Secret"}, "v1"); err != nil {
+		return fmt.Errorf("failed to load the rest mapper: %w", err)
+	}
+
+	debugLog(logger)("clients warmed for namespace %q", settings.Namespace())
+
+	return nil
+}
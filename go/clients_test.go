@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestClientCache(t *testing.T) {
+	env := HelmEnv{kube_config_data: []byte(testKubeConfig), client_cache_ttl: []int64{60}}
+
+	getter := restClientGetter(initSettings(env, "cache"))
+	if restClientGetter(initSettings(env, "cache")) != getter {
+		t.Error("expected the getter to be reused")
+	}
+	if restClientGetter(initSettings(env, "other")) == getter {
+		t.Error("expected another namespace to get its own getter")
+	}
+
+	token := HelmEnv{kube_config_data: []byte(testKubeConfig), kube_token: []string{"other-token"}, client_cache_ttl: []int64{60}}
+	if restClientGetter(initSettings(token, "cache")) == getter {
+		t.Error("expected another token to get its own getter")
+	}
+
+	uncached := HelmEnv{kube_config_data: []byte(testKubeConfig)}
+	if restClientGetter(initSettings(uncached, "cache")) == restClientGetter(initSettings(uncached, "cache")) {
+		t.Error("expected the cache to be disabled without a ttl")
+	}
+
+	resp := Helm{}.warm_clients(&WarmClientsRequest{ns: "cache", env: uncached})
+	if len(resp.err) == 0 {
+		t.Error("expected warming the clients without a ttl to fail")
+	}
+
+	invalidateClients(initSettings(env, "cache"), false)
+	if restClientGetter(initSettings(env, "cache")) == getter {
+		t.Error("expected the getter to be rebuilt after invalidation")
+	}
+
+	getter = restClientGetter(initSettings(env, "cache"))
+	invalidateClients(nil, true)
+	if restClientGetter(initSettings(env, "cache")) == getter {
+		t.Error("expected the getter to be rebuilt after invalidating all clients")
+	}
+}
+
+func TestWarmClientsFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	env := HelmEnv{kube_config_data: []byte(testKubeConfig), kube_api_server: []string{server.URL}, client_cache_ttl: []int64{60}}
+	getter := restClientGetter(initSettings(env, "warm"))
+
+	resp := Helm{}.warm_clients(&WarmClientsRequest{ns: "warm", env: env})
+	if len(resp.err) == 0 {
+		t.Fatal("expected warming the clients of an unavailable cluster to fail")
+	}
+	if restClientGetter(initSettings(env, "warm")) == getter {
+		t.Error("expected the clients to be forgotten after a failed warm up")
+	}
+}
+
+// discoveryServer serves the core api with secrets, and counts the requests.
+func discoveryServer(t *testing.T) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api":
+			fmt.Fprint(w, `{"kind":"APIVersions","versions":["v1"]}`)
+		case "/apis":
+			fmt.Fprint(w, `{"kind":"APIGroupList","apiVersion":"v1","groups":[]}`)
+		case "/api/v1":
+			fmt.Fprint(w, `{"kind":"APIResourceList","groupVersion":"v1","resources":[{"name":"secrets","singularName":"secret","namespaced":true,"kind":"Secret","verbs":["get","list"]}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+// deferredMapper returns the mapper behind the shortcut expander, the
+// expander itself is a struct value that can't be compared.
+func deferredMapper(mapper meta.RESTMapper) any {
+	return reflect.ValueOf(mapper).FieldByName("RESTMapper").Interface()
+}
+
+func TestWarmClientsMemoized(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	server, requests := discoveryServer(t)
+
+	kubeConfig := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeConfig, []byte(strings.ReplaceAll(testKubeConfig, "https://dev.example.com", server.URL)), 0o600); err != nil {
+		t.Fatalf("failed to write the kubeconfig: %v", err)
+	}
+
+	for name, env := range map[string]HelmEnv{
+		"kubeconfig": {kube_config: []string{kubeConfig}, client_cache_ttl: []int64{60}},
+		"inline":     {kube_config_data: []byte(testKubeConfig), kube_api_server: []string{server.URL}, client_cache_ttl: []int64{60}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := Helm{}.warm_clients(&WarmClientsRequest{ns: "memoized", env: env})
+			if len(resp.err) > 0 {
+				t.Fatalf("warm clients failed: %v", resp.err)
+			}
+			warmed := requests.Load()
+
+			getter := restClientGetter(initSettings(env, "memoized"))
+			first, err := getter.ToDiscoveryClient()
+			if err != nil {
+				t.Fatalf("failed to get the discovery client: %v", err)
+			}
+			second, _ := getter.ToDiscoveryClient()
+			if first != second {
+				t.Error("expected the discovery client to be reused")
+			}
+
+			mapper, err := getter.ToRESTMapper()
+			if err != nil {
+				t.Fatalf("failed to get the rest mapper: %v", err)
+			}
+			again, _ := getter.ToRESTMapper()
+			if deferredMapper(mapper) != deferredMapper(again) {
+				t.Error("expected the rest mapper to be reused")
+			}
+
+			if _, err := mapper.RESTMapping(schema.GroupKind{Kind: "Secret"}, "v1"); err != nil {
+				t.Fatalf("failed to map secrets: %v", err)
+			}
+			if requests.Load() != warmed {
+				t.Errorf("expected no discovery after warming, got %d requests", requests.Load()-warmed)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/storage/driver"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+// Error kinds sent along with the error messages, the rust side maps them to
+// ErrorKind. An empty kind means the error could not be classified.
+const (
+	errKindUnknown          = ""
+	errKindReleaseNotFound  = "release_not_found"
+	errKindReleaseExists    = "release_exists"
+	errKindPending          = "pending"
+	errKindLocked           = "locked"
+	errKindTimeout          = "timeout"
+	errKindCancelled        = "cancelled"
+	errKindKubeNotFound     = "kube_not_found"
+	errKindKubeConflict     = "kube_conflict"
+	errKindKubeForbidden    = "kube_forbidden"
+	errKindKubeUnauthorized = "kube_unauthorized"
+	errKindKubeInvalid      = "kube_invalid"
+	errKindKube             = "kube"
+	errKindAuth             = "auth"
+	errKindChartNotFound    = "chart_not_found"
+	errKindChartInvalid     = "chart_invalid"
+)
+
+// Helm doesn't export these errors, so they are matched on their message
+const (
+	errPendingMessage   = "another operation (install/upgrade/rollback) is in progress"
+	errNameInUseMessage = "cannot re-use a name that is still in use"
+	errSchemaMessage    = "values don't meet the specifications of the schema(s)"
+)
+
+// kindError tags an error with a kind when the cause can't be told from the
+// error chain, like a chart that failed to load.
+type kindError struct {
+	kind string
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() error {
+	return e.err
+}
+
+func withKind(kind string, err error) error {
+	return &kindError{kind: kind, err: err}
+}
+
+// errorKind classifies err, causes found in the error chain take precedence
+// over the kind the error was tagged with.
+func errorKind(err error) string {
+	if err == nil {
+		return errKindUnknown
+	}
+
+	msg := err.Error()
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return errKindCancelled
+	case errors.Is(err, context.DeadlineExceeded):
+		return errKindTimeout
+	case errors.Is(err, driver.ErrReleaseNotFound):
+		return errKindReleaseNotFound
+	case errors.Is(err, driver.ErrReleaseExists), strings.Contains(msg, errNameInUseMessage):
+		return errKindReleaseExists
+	case strings.Contains(msg, errPendingMessage):
+		return errKindPending
+	case errors.Is(err, errReleaseLocked):
+		return errKindLocked
+	case isAuthError(err):
+		return errKindAuth
+	case strings.Contains(msg, errSchemaMessage):
+		return errKindChartInvalid
+	}
+
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		switch apierrors.ReasonForError(err) {
+		case metav1.StatusReasonNotFound:
+			return errKindKubeNotFound
+		case metav1.StatusReasonConflict, metav1.StatusReasonAlreadyExists:
+			return errKindKubeConflict
+		case metav1.StatusReasonForbidden:
+			return errKindKubeForbidden
+		case metav1.StatusReasonUnauthorized:
+			return errKindKubeUnauthorized
+		case metav1.StatusReasonInvalid, metav1.StatusReasonBadRequest:
+			return errKindKubeInvalid
+		case metav1.StatusReasonTimeout, metav1.StatusReasonServerTimeout:
+			return errKindTimeout
+		default:
+			return errKindKube
+		}
+	}
+
+	var tagged *kindError
+	if errors.As(err, &tagged) {
+		return tagged.kind
+	}
+
+	return errKindUnknown
+}
+
+// isAuthError reports whether err comes from a registry or chart repository
+// rejecting the credentials.
+func isAuthError(err error) bool {
+	if errors.Is(err, auth.ErrBasicCredentialNotFound) {
+		return true
+	}
+
+	var response *errcode.ErrorResponse
+	if errors.As(err, &response) {
+		return response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden
+	}
+
+	// The http getter used for chart repositories only reports the status line
+	msg := err.Error()
+	for _, code := range []int{http.StatusUnauthorized, http.StatusForbidden} {
+		if strings.Contains(msg, fmt.Sprintf(": %d %s", code, http.StatusText(code))) {
+			return true
+		}
+	}
+
+	return false
+}
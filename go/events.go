@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event kinds sent to the rust side
+const (
+	eventPhase = "phase"
+	eventLog   = "log"
+	eventWait  = "wait"
+)
+
+// Phases of install, upgrade and uninstall. The ones helm goes through inside
+// the action are detected from its log lines.
+const (
+	phaseLocating     = "locating"
+	phaseLoading      = "loading"
+	phaseDependencies = "dependencies"
+	phaseRendering    = "rendering"
+	phaseApplying     = "applying"
+	phaseHooks        = "hooks"
+	phaseWaiting      = "waiting"
+	phaseUninstalling = "uninstalling"
+)
+
+// eventsTTL bounds how long a stream is kept around after it is done, or
+// before it is opened, waiting for the rust side to drain it.
+const eventsTTL = time.Minute
+
+// eventsPollTimeout bounds how long next_events holds the request open when
+// there are no new events.
+const eventsPollTimeout = time.Second
+
+var logPhases = []struct {
+	prefix string
+	phase  string
+}{
+	{"preparing upgrade for", phaseRendering},
+	{"creating ", phaseApplying},
+	{"checking ", phaseApplying},
+	{"Watching for changes to", phaseHooks},
+	{"beginning wait for", phaseWaiting},
+}
+
+// readinessLine matches the ready checker lines, like
+// "Deployment is not ready: default/podinfo. 0 out of 2 expected pods are ready"
+var readinessLine = regexp.MustCompile(`^(\w+) [^:]*: ([^/\s]+)/([^\s.]+)`)
+
+type event struct {
+	Kind     string
+	Phase    string
+	Message  string
+	Resource string
+	Time     time.Time
+}
+
+// eventStream buffers the events of a request until the rust side polls them.
+type eventStream struct {
+	mu      sync.Mutex
+	events  []event
+	phase   string
+	opened  bool
+	done    bool
+	updated time.Time
+	notify  chan struct{}
+}
+
+// streams tracks event streams by the id assigned on the rust side.
+var streams = struct {
+	sync.Mutex
+	byID map[uint64]*eventStream
+}{
+	byID: map[uint64]*eventStream{},
+}
+
+// streamFor returns the stream with the given id, creating it if needed.
+func streamFor(id uint64) *eventStream {
+	streams.Lock()
+	defer streams.Unlock()
+
+	now := time.Now()
+	for streamID, stream := range streams.byID {
+		stream.mu.Lock()
+		if (stream.done || !stream.opened) && now.Sub(stream.updated) > eventsTTL {
+			delete(streams.byID, streamID)
+		}
+		stream.mu.Unlock()
+	}
+
+	stream, ok := streams.byID[id]
+	if !ok {
+		stream = &eventStream{updated: now, notify: make(chan struct{}, 1)}
+		streams.byID[id] = stream
+	}
+
+	return stream
+}
+
+// registerEvents creates the stream of a request before it is sent, the rust
+// side can poll it before the operation opens it.
+func registerEvents(id uint64) {
+	if id != 0 {
+		streamFor(id)
+	}
+}
+
+// openEvents returns the stream of the request, or nil when the request did
+// not ask for events. All eventStream methods accept a nil stream.
+func openEvents(id uint64, enabled bool) *eventStream {
+	if id == 0 || !enabled {
+		return nil
+	}
+
+	stream := streamFor(id)
+	stream.mu.Lock()
+	stream.opened = true
+	stream.mu.Unlock()
+
+	return stream
+}
+
+func (s *eventStream) emit(e event) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	e.Time = time.Now()
+	if e.Kind == eventPhase {
+		s.phase = e.Phase
+	}
+	e.Phase = s.phase
+	s.events = append(s.events, e)
+	s.updated = e.Time
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// enter emits a phase change.
+func (s *eventStream) enter(phase string) {
+	s.emit(event{Kind: eventPhase, Phase: phase, Message: phase})
+}
+
+// close marks the stream as done, the rust side stops polling once it got
+// the remaining events.
+func (s *eventStream) close() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.done = true
+	s.updated = time.Now()
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// next waits until there are events or the stream is done, and takes them.
+func (s *eventStream) next(timeout time.Duration) ([]event, bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		events, done := s.events, s.done
+		s.events = nil
+		s.mu.Unlock()
+
+		if len(events) > 0 || done {
+			return events, done
+		}
+
+		select {
+		case <-s.notify:
+		case <-timer.C:
+			return nil, false
+		}
+	}
+}
+
+// Write turns the helm log lines into events, it is used as the output of the
+// request logger.
+func (s *eventStream) Write(p []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(line, debugPrefix))
+		if line == "" {
+			continue
+		}
+
+		for _, lp := range logPhases {
+			if strings.HasPrefix(line, lp.prefix) && s.currentPhase() != lp.phase {
+				s.enter(lp.phase)
+				break
+			}
+		}
+
+		e := event{Kind: eventLog, Message: line}
+		if match := readinessLine.FindStringSubmatch(line); match != nil && s.currentPhase() == phaseWaiting {
+			e.Kind = eventWait
+			e.Resource = fmt.Sprintf("%s/%s/%s", match[1], match[2], match[3])
+		}
+		s.emit(e)
+	}
+
+	return len(p), nil
+}
+
+func (s *eventStream) currentPhase() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.phase
+}
+
+// logger returns a logger writing to base, and to the stream when there is one.
+func (s *eventStream) logger(base *log.Logger) *log.Logger {
+	if s == nil {
+		return base
+	}
+
+	return log.New(eventWriter{stream: s, base: base}, "", 0)
+}
+
+// eventWriter forwards the log lines to the stream and the base logger, so
+// the base logger keeps its prefix and flags.
+type eventWriter struct {
+	stream *eventStream
+	base   *log.Logger
+}
+
+func (w eventWriter) Write(p []byte) (int, error) {
+	w.base.Print(string(p))
+
+	return w.stream.Write(p)
+}
+
+type eventsKey struct{}
+
+// withEvents attaches the stream to the context, so the run functions can
+// emit the phases they go through.
+func withEvents(ctx context.Context, s *eventStream) context.Context {
+	return context.WithValue(ctx, eventsKey{}, s)
+}
+
+// eventsFrom returns the stream attached to the context, or nil.
+func eventsFrom(ctx context.Context) *eventStream {
+	s, _ := ctx.Value(eventsKey{}).(*eventStream)
+
+	return s
+}
+
+// nextEvents waits for the events of the request with the given id, and
+// forgets the stream once it is done and drained.
+func nextEvents(id uint64) ([]event, bool) {
+	streams.Lock()
+	stream, ok := streams.byID[id]
+	streams.Unlock()
+	// The stream was drained already or never registered, nothing will come
+	if !ok {
+		return nil, true
+	}
+
+	events, done := stream.next(eventsPollTimeout)
+	if done {
+		streams.Lock()
+		delete(streams.byID, id)
+		streams.Unlock()
+	}
+
+	return events, done
+}
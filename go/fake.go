@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"helm.sh/helm/v3/pkg/kube"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+)
+
+// Fake kube clients selected through HelmEnv, so the calls can run without a
+// cluster. They are meant to be used with the memory driver.
+const (
+	// fakeKubePrinting accepts every change without applying it
+	fakeKubePrinting = "printing"
+	// fakeKubeFailing fails every change made to the cluster
+	fakeKubeFailing = "failing"
+	// fakeKubeWaiting accepts every change, its resources never get ready
+	// before the timeout
+	fakeKubeWaiting = "waiting"
+)
+
+var errFakeKube = errors.New("fake kube client failure")
+
+func fakeKubeClient(name string) (kube.Interface, error) {
+	printing := kubefake.PrintingKubeClient{Out: io.Discard}
+
+	switch name {
+	case fakeKubePrinting:
+		return &printing, nil
+	case fakeKubeWaiting:
+		return &waitingKubeClient{PrintingKubeClient: printing}, nil
+	case fakeKubeFailing:
+		return &kubefake.FailingKubeClient{
+			PrintingKubeClient:         printing,
+			CreateError:                errFakeKube,
+			WaitError:                  errFakeKube,
+			DeleteError:                errFakeKube,
+			DeleteWithPropagationError: errFakeKube,
+			WatchUntilReadyError:       errFakeKube,
+			UpdateError:                errFakeKube,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown fake kube client %q", name)
+	}
+}
+
+// fakeWaits is signaled when a wait of the waiting kube client starts, so
+// tests know the operation reached it.
+var fakeWaits = make(chan struct{}, 16)
+
+// waitingKubeClient waits for the resources until the timeout.
+type waitingKubeClient struct {
+	kubefake.PrintingKubeClient
+}
+
+func (c *waitingKubeClient) Wait(_ kube.ResourceList, timeout time.Duration) error {
+	select {
+	case fakeWaits <- struct{}{}:
+	default:
+	}
+	time.Sleep(timeout)
+
+	return fmt.Errorf("timed out waiting for the resources: %w", errFakeKube)
+}
+
+func (c *waitingKubeClient) WaitWithJobs(resources kube.ResourceList, timeout time.Duration) error {
+	return c.Wait(resources, timeout)
+}
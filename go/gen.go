@@ -24,6 +24,23 @@ typedef struct HelmEnvRef {
   struct ListRef kube_token;
   struct ListRef kube_ca_file;
   bool kube_insecure_skip_tls_verify;
+  struct ListRef kube_api_server;
+  struct ListRef kube_as_user;
+  struct ListRef kube_as_groups;
+  struct ListRef kube_tls_server_name;
+  struct ListRef burst_limit;
+  struct ListRef qps;
+  struct ListRef kube_config_data;
+  struct ListRef kube_ca_data;
+  struct ListRef kube_client_cert_data;
+  struct ListRef kube_client_key_data;
+  bool kube_in_cluster;
+  bool debug;
+  struct ListRef log_level;
+  struct ListRef driver;
+  struct ListRef driver_connection;
+  struct ListRef fake_kube_client;
+  struct ListRef client_cache_ttl;
 } HelmEnvRef;
 
 typedef struct AddRequestRef {
@@ -44,8 +61,61 @@ typedef struct AddRequestRef {
 
 typedef struct AddResponseRef {
   struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
 } AddResponseRef;
 
+typedef struct CancelRequestRef {
+  uint64_t id;
+} CancelRequestRef;
+
+typedef struct EventsRequestRef {
+  uint64_t id;
+} EventsRequestRef;
+
+typedef struct ProgressEventRef {
+  struct StringRef kind;
+  struct StringRef phase;
+  struct StringRef message;
+  struct StringRef resource;
+  int64_t time;
+} ProgressEventRef;
+
+typedef struct EventsResponseRef {
+  struct ListRef events;
+  bool done;
+} EventsResponseRef;
+
+typedef struct GetRequestRef {
+  struct StringRef ns;
+  struct StringRef release_name;
+  struct StringRef what;
+  int64_t revision;
+  struct HelmEnvRef env;
+} GetRequestRef;
+
+typedef struct GetResponseRef {
+  struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
+  struct ListRef json;
+  struct StringRef text;
+} GetResponseRef;
+
+typedef struct HistoryRequestRef {
+  struct StringRef ns;
+  struct StringRef release_name;
+  int64_t max;
+  struct HelmEnvRef env;
+} HistoryRequestRef;
+
+typedef struct HistoryResponseRef {
+  struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
+  struct StringRef data;
+} HistoryResponseRef;
+
 typedef struct InstallRequestRef {
   struct StringRef release_name;
   struct StringRef chart;
@@ -57,13 +127,91 @@ typedef struct InstallRequestRef {
   struct ListRef values;
   struct HelmEnvRef env;
   struct ListRef dry_run;
+  uint64_t id;
+  bool atomic;
+  bool wait_for_jobs;
+  struct ListRef values_files;
+  struct ListRef set_values;
+  struct ListRef set_string_values;
+  struct ListRef set_json_values;
+  struct ListRef set_file_values;
+  struct ListRef set_literal_values;
+  bool events;
+  struct ListRef lock_timeout;
+  bool dependency_update;
+  struct StringRef cert_file;
+  struct StringRef key_file;
+  struct StringRef ca_file;
+  bool insecure;
+  bool plain_http;
+  struct StringRef username;
+  struct StringRef password;
+  bool pass_credentials_all;
+  struct StringRef repo_url;
+  struct StringRef keyring;
+  bool verify;
 } InstallRequestRef;
 
+typedef struct ReleaseSummaryRef {
+  struct StringRef name;
+  struct StringRef namespace;
+  int64_t revision;
+  struct StringRef status;
+  struct StringRef chart_name;
+  struct StringRef chart_version;
+  struct StringRef app_version;
+  struct StringRef first_deployed;
+  struct StringRef last_deployed;
+  struct StringRef description;
+  struct StringRef notes;
+} ReleaseSummaryRef;
+
 typedef struct InstallResponseRef {
   struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
   struct StringRef data;
+  bool cancelled;
+  bool uninstalled;
+  int64_t revision;
+  struct ListRef release;
+  int64_t lock_wait;
 } InstallResponseRef;
 
+typedef struct InvalidateClientsRequestRef {
+  struct StringRef ns;
+  struct HelmEnvRef env;
+  bool all;
+} InvalidateClientsRequestRef;
+
+typedef struct ListPageRequestRef {
+  struct StringRef ns;
+  struct HelmEnvRef env;
+  bool all;
+  bool all_namespaces;
+  uint64_t state_mask;
+  bool deployed;
+  bool failed;
+  bool pending;
+  bool uninstalled;
+  bool uninstalling;
+  bool superseded;
+  struct StringRef filter;
+  struct StringRef selector;
+  bool by_date;
+  bool sort_reverse;
+  int64_t page_size;
+  struct StringRef cursor;
+} ListPageRequestRef;
+
+typedef struct ListPageResponseRef {
+  struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
+  struct ListRef releases;
+  struct StringRef next_cursor;
+} ListPageResponseRef;
+
 typedef struct ListRequestRef {
   struct StringRef ns;
   struct HelmEnvRef env;
@@ -89,7 +237,10 @@ typedef struct ListRequestRef {
 
 typedef struct ListResponseRef {
   struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
   struct StringRef data;
+  struct ListRef releases;
 } ListResponseRef;
 
 typedef struct LoginRequestRef {
@@ -106,8 +257,138 @@ typedef struct LoginRequestRef {
 
 typedef struct LoginResponseRef {
   struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
 } LoginResponseRef;
 
+typedef struct PackageRequestRef {
+  struct StringRef path;
+  struct StringRef destination;
+  struct StringRef version;
+  struct StringRef app_version;
+  bool dependency_update;
+  bool sign;
+  struct StringRef key;
+  struct StringRef keyring;
+  struct StringRef passphrase_file;
+  struct HelmEnvRef env;
+} PackageRequestRef;
+
+typedef struct PackageResponseRef {
+  struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
+  struct StringRef path;
+} PackageResponseRef;
+
+typedef struct PullRequestRef {
+  struct StringRef chart;
+  struct StringRef version;
+  struct StringRef dest_dir;
+  bool untar;
+  struct StringRef untar_dir;
+  bool prov;
+  bool devel;
+  struct StringRef cert_file;
+  struct StringRef key_file;
+  struct StringRef ca_file;
+  bool insecure;
+  bool plain_http;
+  struct StringRef username;
+  struct StringRef password;
+  bool pass_credentials_all;
+  struct StringRef repo_url;
+  struct StringRef keyring;
+  bool verify;
+  struct HelmEnvRef env;
+} PullRequestRef;
+
+typedef struct PullResponseRef {
+  struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
+  struct StringRef path;
+  struct StringRef digest;
+} PullResponseRef;
+
+typedef struct PushRequestRef {
+  struct StringRef chart;
+  struct StringRef remote;
+  struct StringRef cert_file;
+  struct StringRef key_file;
+  struct StringRef ca_file;
+  bool insecure;
+  bool plain_http;
+  struct HelmEnvRef env;
+} PushRequestRef;
+
+typedef struct PushResponseRef {
+  struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
+  struct StringRef pushed_ref;
+  struct StringRef digest;
+} PushResponseRef;
+
+typedef struct RecoverRequestRef {
+  struct StringRef ns;
+  struct StringRef release_name;
+  struct StringRef policy;
+  int64_t min_age;
+  bool wait;
+  struct ListRef timeout;
+  uint64_t id;
+  struct ListRef lock_timeout;
+  struct HelmEnvRef env;
+} RecoverRequestRef;
+
+typedef struct RecoverResponseRef {
+  struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
+  struct StringRef data;
+  bool cancelled;
+  struct StringRef status;
+  bool recovered;
+  struct ListRef release;
+  int64_t lock_wait;
+} RecoverResponseRef;
+
+typedef struct ResourceReadinessRef {
+  struct StringRef kind;
+  struct StringRef name;
+  struct StringRef namespace;
+  bool ready;
+  struct StringRef reason;
+} ResourceReadinessRef;
+
+typedef struct RollbackRequestRef {
+  struct StringRef ns;
+  struct StringRef release_name;
+  int64_t revision;
+  bool wait;
+  bool wait_for_jobs;
+  struct ListRef timeout;
+  bool force;
+  bool recreate;
+  bool cleanup_on_fail;
+  bool disable_hooks;
+  bool dry_run;
+  struct HelmEnvRef env;
+  uint64_t id;
+  struct ListRef lock_timeout;
+} RollbackRequestRef;
+
+typedef struct RollbackResponseRef {
+  struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
+  struct StringRef data;
+  bool cancelled;
+  struct ListRef release;
+  int64_t lock_wait;
+} RollbackResponseRef;
+
 typedef struct SearchRequestRef {
   bool versions;
   struct StringRef regexp;
@@ -119,9 +400,61 @@ typedef struct SearchRequestRef {
 
 typedef struct SearchResponseRef {
   struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
   struct StringRef data;
 } SearchResponseRef;
 
+typedef struct StatusRequestRef {
+  struct StringRef ns;
+  struct StringRef release_name;
+  int64_t revision;
+  bool show_resources;
+  struct HelmEnvRef env;
+} StatusRequestRef;
+
+typedef struct StatusResponseRef {
+  struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
+  struct StringRef data;
+  struct ListRef resources;
+} StatusResponseRef;
+
+typedef struct TemplateRequestRef {
+  struct StringRef release_name;
+  struct StringRef chart;
+  struct StringRef version;
+  struct StringRef ns;
+  struct ListRef values;
+  struct ListRef kube_version;
+  struct ListRef api_versions;
+  bool include_crds;
+  bool is_upgrade;
+  struct HelmEnvRef env;
+  bool dependency_update;
+  struct StringRef cert_file;
+  struct StringRef key_file;
+  struct StringRef ca_file;
+  bool insecure;
+  bool plain_http;
+  struct StringRef username;
+  struct StringRef password;
+  bool pass_credentials_all;
+  struct StringRef repo_url;
+  struct StringRef keyring;
+  bool verify;
+} TemplateRequestRef;
+
+typedef struct TemplateResponseRef {
+  struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
+  struct StringRef manifest;
+  struct StringRef notes;
+  struct StringRef hooks;
+} TemplateResponseRef;
+
 typedef struct UninstallRequestRef {
   struct StringRef ns;
   struct StringRef release_name;
@@ -134,11 +467,19 @@ typedef struct UninstallRequestRef {
   struct ListRef timeout;
   struct StringRef description;
   struct HelmEnvRef env;
+  uint64_t id;
+  bool events;
+  struct ListRef lock_timeout;
 } UninstallRequestRef;
 
 typedef struct UninstallResponseRef {
   struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
   struct StringRef data;
+  bool cancelled;
+  struct ListRef release;
+  int64_t lock_wait;
 } UninstallResponseRef;
 
 typedef struct UpgradeRequestRef {
@@ -153,12 +494,61 @@ typedef struct UpgradeRequestRef {
   bool reset_values;
   bool reuse_values;
   struct ListRef dry_run;
+  uint64_t id;
+  bool install;
+  bool create_namespace;
+  bool atomic;
+  bool cleanup_on_fail;
+  bool wait_for_jobs;
+  int64_t max_history;
+  struct ListRef values_files;
+  struct ListRef set_values;
+  struct ListRef set_string_values;
+  struct ListRef set_json_values;
+  struct ListRef set_file_values;
+  struct ListRef set_literal_values;
+  bool events;
+  struct ListRef lock_timeout;
+  bool recover_pending;
+  struct StringRef recover_policy;
+  int64_t recover_pending_after;
+  bool dependency_update;
+  struct StringRef cert_file;
+  struct StringRef key_file;
+  struct StringRef ca_file;
+  bool insecure;
+  bool plain_http;
+  struct StringRef username;
+  struct StringRef password;
+  bool pass_credentials_all;
+  struct StringRef repo_url;
+  struct StringRef keyring;
+  bool verify;
 } UpgradeRequestRef;
 
 typedef struct UpgradeResponseRef {
   struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
   struct StringRef data;
+  bool cancelled;
+  bool rolled_back;
+  bool uninstalled;
+  int64_t revision;
+  struct ListRef release;
+  int64_t lock_wait;
 } UpgradeResponseRef;
+
+typedef struct WarmClientsRequestRef {
+  struct StringRef ns;
+  struct HelmEnvRef env;
+} WarmClientsRequestRef;
+
+typedef struct WarmClientsResponseRef {
+  struct ListRef err;
+  struct StringRef err_kind;
+  struct ListRef logs;
+} WarmClientsResponseRef;
 */
 import "C"
 import (
@@ -173,11 +563,26 @@ var HelmCallImpl HelmCall
 type HelmCall interface {
 	install(req *InstallRequest) InstallResponse
 	upgrade(req *UpgradeRequest) UpgradeResponse
+	template(req *TemplateRequest) TemplateResponse
 	uninstall(req *UninstallRequest) UninstallResponse
 	list(req *ListRequest) ListResponse
+	list_page(req *ListPageRequest) ListPageResponse
+	status(req *StatusRequest) StatusResponse
+	get(req *GetRequest) GetResponse
+	history(req *HistoryRequest) HistoryResponse
+	rollback(req *RollbackRequest) RollbackResponse
+	recover_release(req *RecoverRequest) RecoverResponse
 	repo_add(req *AddRequest) AddResponse
 	repo_search(req *SearchRequest) SearchResponse
 	registry_login(req *LoginRequest) LoginResponse
+	pull(req *PullRequest) PullResponse
+	push(req *PushRequest) PushResponse
+	package_chart(req *PackageRequest) PackageResponse
+	next_events(req *EventsRequest) EventsResponse
+	warm_clients(req *WarmClientsRequest) WarmClientsResponse
+	invalidate_clients(req *InvalidateClientsRequest)
+	open_events(req *EventsRequest)
+	cancel(req *CancelRequest)
 }
 
 //export CHelmCall_install
@@ -206,6 +611,19 @@ func CHelmCall_upgrade(req C.UpgradeRequestRef, slot *C.void, cb *C.void) {
 	}()
 }
 
+//export CHelmCall_template
+func CHelmCall_template(req C.TemplateRequestRef, slot *C.void, cb *C.void) {
+	_new_req := newTemplateRequest(req)
+	go func() {
+		resp := HelmCallImpl.template(&_new_req)
+		resp_ref, buffer := cvt_ref(cntTemplateResponse, refTemplateResponse)(&resp)
+		asmcall.CallFuncG0P2(unsafe.Pointer(cb), unsafe.Pointer(&resp_ref), unsafe.Pointer(slot))
+		runtime.KeepAlive(resp_ref)
+		runtime.KeepAlive(resp)
+		runtime.KeepAlive(buffer)
+	}()
+}
+
 //export CHelmCall_uninstall
 func CHelmCall_uninstall(req C.UninstallRequestRef, slot *C.void, cb *C.void) {
 	_new_req := newUninstallRequest(req)
@@ -232,6 +650,84 @@ func CHelmCall_list(req C.ListRequestRef, slot *C.void, cb *C.void) {
 	}()
 }
 
+//export CHelmCall_list_page
+func CHelmCall_list_page(req C.ListPageRequestRef, slot *C.void, cb *C.void) {
+	_new_req := newListPageRequest(req)
+	go func() {
+		resp := HelmCallImpl.list_page(&_new_req)
+		resp_ref, buffer := cvt_ref(cntListPageResponse, refListPageResponse)(&resp)
+		asmcall.CallFuncG0P2(unsafe.Pointer(cb), unsafe.Pointer(&resp_ref), unsafe.Pointer(slot))
+		runtime.KeepAlive(resp_ref)
+		runtime.KeepAlive(resp)
+		runtime.KeepAlive(buffer)
+	}()
+}
+
+//export CHelmCall_status
+func CHelmCall_status(req C.StatusRequestRef, slot *C.void, cb *C.void) {
+	_new_req := newStatusRequest(req)
+	go func() {
+		resp := HelmCallImpl.status(&_new_req)
+		resp_ref, buffer := cvt_ref(cntStatusResponse, refStatusResponse)(&resp)
+		asmcall.CallFuncG0P2(unsafe.Pointer(cb), unsafe.Pointer(&resp_ref), unsafe.Pointer(slot))
+		runtime.KeepAlive(resp_ref)
+		runtime.KeepAlive(resp)
+		runtime.KeepAlive(buffer)
+	}()
+}
+
+//export CHelmCall_get
+func CHelmCall_get(req C.GetRequestRef, slot *C.void, cb *C.void) {
+	_new_req := newGetRequest(req)
+	go func() {
+		resp := HelmCallImpl.get(&_new_req)
+		resp_ref, buffer := cvt_ref(cntGetResponse, refGetResponse)(&resp)
+		asmcall.CallFuncG0P2(unsafe.Pointer(cb), unsafe.Pointer(&resp_ref), unsafe.Pointer(slot))
+		runtime.KeepAlive(resp_ref)
+		runtime.KeepAlive(resp)
+		runtime.KeepAlive(buffer)
+	}()
+}
+
+//export CHelmCall_history
+func CHelmCall_history(req C.HistoryRequestRef, slot *C.void, cb *C.void) {
+	_new_req := newHistoryRequest(req)
+	go func() {
+		resp := HelmCallImpl.history(&_new_req)
+		resp_ref, buffer := cvt_ref(cntHistoryResponse, refHistoryResponse)(&resp)
+		asmcall.CallFuncG0P2(unsafe.Pointer(cb), unsafe.Pointer(&resp_ref), unsafe.Pointer(slot))
+		runtime.KeepAlive(resp_ref)
+		runtime.KeepAlive(resp)
+		runtime.KeepAlive(buffer)
+	}()
+}
+
+//export CHelmCall_rollback
+func CHelmCall_rollback(req C.RollbackRequestRef, slot *C.void, cb *C.void) {
+	_new_req := newRollbackRequest(req)
+	go func() {
+		resp := HelmCallImpl.rollback(&_new_req)
+		resp_ref, buffer := cvt_ref(cntRollbackResponse, refRollbackResponse)(&resp)
+		asmcall.CallFuncG0P2(unsafe.Pointer(cb), unsafe.Pointer(&resp_ref), unsafe.Pointer(slot))
+		runtime.KeepAlive(resp_ref)
+		runtime.KeepAlive(resp)
+		runtime.KeepAlive(buffer)
+	}()
+}
+
+//export CHelmCall_recover_release
+func CHelmCall_recover_release(req C.RecoverRequestRef, slot *C.void, cb *C.void) {
+	_new_req := newRecoverRequest(req)
+	go func() {
+		resp := HelmCallImpl.recover_release(&_new_req)
+		resp_ref, buffer := cvt_ref(cntRecoverResponse, refRecoverResponse)(&resp)
+		asmcall.CallFuncG0P2(unsafe.Pointer(cb), unsafe.Pointer(&resp_ref), unsafe.Pointer(slot))
+		runtime.KeepAlive(resp_ref)
+		runtime.KeepAlive(resp)
+		runtime.KeepAlive(buffer)
+	}()
+}
+
 //export CHelmCall_repo_add
 func CHelmCall_repo_add(req C.AddRequestRef, slot *C.void, cb *C.void) {
 	_new_req := newAddRequest(req)
@@ -271,6 +767,89 @@ func CHelmCall_registry_login(req C.LoginRequestRef, slot *C.void, cb *C.void) {
 	}()
 }
 
+//export CHelmCall_pull
+func CHelmCall_pull(req C.PullRequestRef, slot *C.void, cb *C.void) {
+	_new_req := newPullRequest(req)
+	go func() {
+		resp := HelmCallImpl.pull(&_new_req)
+		resp_ref, buffer := cvt_ref(cntPullResponse, refPullResponse)(&resp)
+		asmcall.CallFuncG0P2(unsafe.Pointer(cb), unsafe.Pointer(&resp_ref), unsafe.Pointer(slot))
+		runtime.KeepAlive(resp_ref)
+		runtime.KeepAlive(resp)
+		runtime.KeepAlive(buffer)
+	}()
+}
+
+//export CHelmCall_push
+func CHelmCall_push(req C.PushRequestRef, slot *C.void, cb *C.void) {
+	_new_req := newPushRequest(req)
+	go func() {
+		resp := HelmCallImpl.push(&_new_req)
+		resp_ref, buffer := cvt_ref(cntPushResponse, refPushResponse)(&resp)
+		asmcall.CallFuncG0P2(unsafe.Pointer(cb), unsafe.Pointer(&resp_ref), unsafe.Pointer(slot))
+		runtime.KeepAlive(resp_ref)
+		runtime.KeepAlive(resp)
+		runtime.KeepAlive(buffer)
+	}()
+}
+
+//export CHelmCall_package_chart
+func CHelmCall_package_chart(req C.PackageRequestRef, slot *C.void, cb *C.void) {
+	_new_req := newPackageRequest(req)
+	go func() {
+		resp := HelmCallImpl.package_chart(&_new_req)
+		resp_ref, buffer := cvt_ref(cntPackageResponse, refPackageResponse)(&resp)
+		asmcall.CallFuncG0P2(unsafe.Pointer(cb), unsafe.Pointer(&resp_ref), unsafe.Pointer(slot))
+		runtime.KeepAlive(resp_ref)
+		runtime.KeepAlive(resp)
+		runtime.KeepAlive(buffer)
+	}()
+}
+
+//export CHelmCall_next_events
+func CHelmCall_next_events(req C.EventsRequestRef, slot *C.void, cb *C.void) {
+	_new_req := newEventsRequest(req)
+	go func() {
+		resp := HelmCallImpl.next_events(&_new_req)
+		resp_ref, buffer := cvt_ref(cntEventsResponse, refEventsResponse)(&resp)
+		asmcall.CallFuncG0P2(unsafe.Pointer(cb), unsafe.Pointer(&resp_ref), unsafe.Pointer(slot))
+		runtime.KeepAlive(resp_ref)
+		runtime.KeepAlive(resp)
+		runtime.KeepAlive(buffer)
+	}()
+}
+
+//export CHelmCall_warm_clients
+func CHelmCall_warm_clients(req C.WarmClientsRequestRef, slot *C.void, cb *C.void) {
+	_new_req := newWarmClientsRequest(req)
+	go func() {
+		resp := HelmCallImpl.warm_clients(&_new_req)
+		resp_ref, buffer := cvt_ref(cntWarmClientsResponse, refWarmClientsResponse)(&resp)
+		asmcall.CallFuncG0P2(unsafe.Pointer(cb), unsafe.Pointer(&resp_ref), unsafe.Pointer(slot))
+		runtime.KeepAlive(resp_ref)
+		runtime.KeepAlive(resp)
+		runtime.KeepAlive(buffer)
+	}()
+}
+
+//export CHelmCall_invalidate_clients
+func CHelmCall_invalidate_clients(req C.InvalidateClientsRequestRef) {
+	_new_req := newInvalidateClientsRequest(req)
+	HelmCallImpl.invalidate_clients(&_new_req)
+}
+
+//export CHelmCall_open_events
+func CHelmCall_open_events(req C.EventsRequestRef) {
+	_new_req := newEventsRequest(req)
+	HelmCallImpl.open_events(&_new_req)
+}
+
+//export CHelmCall_cancel
+func CHelmCall_cancel(req C.CancelRequestRef) {
+	_new_req := newCancelRequest(req)
+	HelmCallImpl.cancel(&_new_req)
+}
+
 func newString(s_ref C.StringRef) string {
 	return unsafe.String((*byte)(unsafe.Pointer(s_ref.ptr)), s_ref.len)
 }
@@ -422,128 +1001,462 @@ func refC_float(p *float32, _ *[]byte) C.float      { return C.float(*p) }
 func refC_double(p *float64, _ *[]byte) C.double    { return C.double(*p) }
 
 type InstallRequest struct {
-	release_name     string
-	chart            string
-	version          string
-	ns               string
-	wait             bool
-	timeout          []int64
-	create_namespace bool
-	values           []uint8
-	env              HelmEnv
-	dry_run          []string
+	release_name         string
+	chart                string
+	version              string
+	ns                   string
+	wait                 bool
+	timeout              []int64
+	create_namespace     bool
+	values               []uint8
+	env                  HelmEnv
+	dry_run              []string
+	id                   uint64
+	atomic               bool
+	wait_for_jobs        bool
+	values_files         []string
+	set_values           []string
+	set_string_values    []string
+	set_json_values      []string
+	set_file_values      []string
+	set_literal_values   []string
+	events               bool
+	lock_timeout         []int64
+	dependency_update    bool
+	cert_file            string
+	key_file             string
+	ca_file              string
+	insecure             bool
+	plain_http           bool
+	username             string
+	password             string
+	pass_credentials_all bool
+	repo_url             string
+	keyring              string
+	verify               bool
 }
 
 func newInstallRequest(p C.InstallRequestRef) InstallRequest {
 	return InstallRequest{
-		release_name:     newString(p.release_name),
-		chart:            newString(p.chart),
-		version:          newString(p.version),
-		ns:               newString(p.ns),
-		wait:             newC_bool(p.wait),
-		timeout:          new_list_mapper_primitive(newC_int64_t)(p.timeout),
-		create_namespace: newC_bool(p.create_namespace),
-		values:           new_list_mapper_primitive(newC_uint8_t)(p.values),
-		env:              newHelmEnv(p.env),
-		dry_run:          new_list_mapper(newString)(p.dry_run),
+		release_name:         newString(p.release_name),
+		chart:                newString(p.chart),
+		version:              newString(p.version),
+		ns:                   newString(p.ns),
+		wait:                 newC_bool(p.wait),
+		timeout:              new_list_mapper_primitive(newC_int64_t)(p.timeout),
+		create_namespace:     newC_bool(p.create_namespace),
+		values:               new_list_mapper_primitive(newC_uint8_t)(p.values),
+		env:                  newHelmEnv(p.env),
+		dry_run:              new_list_mapper(newString)(p.dry_run),
+		id:                   newC_uint64_t(p.id),
+		atomic:               newC_bool(p.atomic),
+		wait_for_jobs:        newC_bool(p.wait_for_jobs),
+		values_files:         new_list_mapper(newString)(p.values_files),
+		set_values:           new_list_mapper(newString)(p.set_values),
+		set_string_values:    new_list_mapper(newString)(p.set_string_values),
+		set_json_values:      new_list_mapper(newString)(p.set_json_values),
+		set_file_values:      new_list_mapper(newString)(p.set_file_values),
+		set_literal_values:   new_list_mapper(newString)(p.set_literal_values),
+		events:               newC_bool(p.events),
+		lock_timeout:         new_list_mapper_primitive(newC_int64_t)(p.lock_timeout),
+		dependency_update:    newC_bool(p.dependency_update),
+		cert_file:            newString(p.cert_file),
+		key_file:             newString(p.key_file),
+		ca_file:              newString(p.ca_file),
+		insecure:             newC_bool(p.insecure),
+		plain_http:           newC_bool(p.plain_http),
+		username:             newString(p.username),
+		password:             newString(p.password),
+		pass_credentials_all: newC_bool(p.pass_credentials_all),
+		repo_url:             newString(p.repo_url),
+		keyring:              newString(p.keyring),
+		verify:               newC_bool(p.verify),
 	}
 }
 func ownInstallRequest(p C.InstallRequestRef) InstallRequest {
 	return InstallRequest{
-		release_name:     ownString(p.release_name),
-		chart:            ownString(p.chart),
-		version:          ownString(p.version),
-		ns:               ownString(p.ns),
-		wait:             newC_bool(p.wait),
-		timeout:          new_list_mapper(newC_int64_t)(p.timeout),
-		create_namespace: newC_bool(p.create_namespace),
-		values:           new_list_mapper(newC_uint8_t)(p.values),
-		env:              ownHelmEnv(p.env),
-		dry_run:          new_list_mapper(ownString)(p.dry_run),
-	}
-}
-func cntInstallRequest(s *InstallRequest, cnt *uint) [0]C.InstallRequestRef {
+		release_name:         ownString(p.release_name),
+		chart:                ownString(p.chart),
+		version:              ownString(p.version),
+		ns:                   ownString(p.ns),
+		wait:                 newC_bool(p.wait),
+		timeout:              new_list_mapper(newC_int64_t)(p.timeout),
+		create_namespace:     newC_bool(p.create_namespace),
+		values:               new_list_mapper(newC_uint8_t)(p.values),
+		env:                  ownHelmEnv(p.env),
+		dry_run:              new_list_mapper(ownString)(p.dry_run),
+		id:                   newC_uint64_t(p.id),
+		atomic:               newC_bool(p.atomic),
+		wait_for_jobs:        newC_bool(p.wait_for_jobs),
+		values_files:         new_list_mapper(ownString)(p.values_files),
+		set_values:           new_list_mapper(ownString)(p.set_values),
+		set_string_values:    new_list_mapper(ownString)(p.set_string_values),
+		set_json_values:      new_list_mapper(ownString)(p.set_json_values),
+		set_file_values:      new_list_mapper(ownString)(p.set_file_values),
+		set_literal_values:   new_list_mapper(ownString)(p.set_literal_values),
+		events:               newC_bool(p.events),
+		lock_timeout:         new_list_mapper(newC_int64_t)(p.lock_timeout),
+		dependency_update:    newC_bool(p.dependency_update),
+		cert_file:            ownString(p.cert_file),
+		key_file:             ownString(p.key_file),
+		ca_file:              ownString(p.ca_file),
+		insecure:             newC_bool(p.insecure),
+		plain_http:           newC_bool(p.plain_http),
+		username:             ownString(p.username),
+		password:             ownString(p.password),
+		pass_credentials_all: newC_bool(p.pass_credentials_all),
+		repo_url:             ownString(p.repo_url),
+		keyring:              ownString(p.keyring),
+		verify:               newC_bool(p.verify),
+	}
+}
+func cntInstallRequest(s *InstallRequest, cnt *uint) [0]C.InstallRequestRef {
 	cntHelmEnv(&s.env, cnt)
 	cnt_list_mapper(cntString)(&s.dry_run, cnt)
+	cnt_list_mapper(cntString)(&s.values_files, cnt)
+	cnt_list_mapper(cntString)(&s.set_values, cnt)
+	cnt_list_mapper(cntString)(&s.set_string_values, cnt)
+	cnt_list_mapper(cntString)(&s.set_json_values, cnt)
+	cnt_list_mapper(cntString)(&s.set_file_values, cnt)
+	cnt_list_mapper(cntString)(&s.set_literal_values, cnt)
 	return [0]C.InstallRequestRef{}
 }
 func refInstallRequest(p *InstallRequest, buffer *[]byte) C.InstallRequestRef {
 	return C.InstallRequestRef{
-		release_name:     refString(&p.release_name, buffer),
-		chart:            refString(&p.chart, buffer),
-		version:          refString(&p.version, buffer),
-		ns:               refString(&p.ns, buffer),
-		wait:             refC_bool(&p.wait, buffer),
-		timeout:          ref_list_mapper_primitive(refC_int64_t)(&p.timeout, buffer),
-		create_namespace: refC_bool(&p.create_namespace, buffer),
-		values:           ref_list_mapper_primitive(refC_uint8_t)(&p.values, buffer),
-		env:              refHelmEnv(&p.env, buffer),
-		dry_run:          ref_list_mapper(refString)(&p.dry_run, buffer),
+		release_name:         refString(&p.release_name, buffer),
+		chart:                refString(&p.chart, buffer),
+		version:              refString(&p.version, buffer),
+		ns:                   refString(&p.ns, buffer),
+		wait:                 refC_bool(&p.wait, buffer),
+		timeout:              ref_list_mapper_primitive(refC_int64_t)(&p.timeout, buffer),
+		create_namespace:     refC_bool(&p.create_namespace, buffer),
+		values:               ref_list_mapper_primitive(refC_uint8_t)(&p.values, buffer),
+		env:                  refHelmEnv(&p.env, buffer),
+		dry_run:              ref_list_mapper(refString)(&p.dry_run, buffer),
+		id:                   refC_uint64_t(&p.id, buffer),
+		atomic:               refC_bool(&p.atomic, buffer),
+		wait_for_jobs:        refC_bool(&p.wait_for_jobs, buffer),
+		values_files:         ref_list_mapper(refString)(&p.values_files, buffer),
+		set_values:           ref_list_mapper(refString)(&p.set_values, buffer),
+		set_string_values:    ref_list_mapper(refString)(&p.set_string_values, buffer),
+		set_json_values:      ref_list_mapper(refString)(&p.set_json_values, buffer),
+		set_file_values:      ref_list_mapper(refString)(&p.set_file_values, buffer),
+		set_literal_values:   ref_list_mapper(refString)(&p.set_literal_values, buffer),
+		events:               refC_bool(&p.events, buffer),
+		lock_timeout:         ref_list_mapper_primitive(refC_int64_t)(&p.lock_timeout, buffer),
+		dependency_update:    refC_bool(&p.dependency_update, buffer),
+		cert_file:            refString(&p.cert_file, buffer),
+		key_file:             refString(&p.key_file, buffer),
+		ca_file:              refString(&p.ca_file, buffer),
+		insecure:             refC_bool(&p.insecure, buffer),
+		plain_http:           refC_bool(&p.plain_http, buffer),
+		username:             refString(&p.username, buffer),
+		password:             refString(&p.password, buffer),
+		pass_credentials_all: refC_bool(&p.pass_credentials_all, buffer),
+		repo_url:             refString(&p.repo_url, buffer),
+		keyring:              refString(&p.keyring, buffer),
+		verify:               refC_bool(&p.verify, buffer),
 	}
 }
 
 type UpgradeRequest struct {
-	release_name string
-	chart        string
-	version      string
-	ns           string
-	wait         bool
-	timeout      []int64
-	values       []uint8
-	env          HelmEnv
-	reset_values bool
-	reuse_values bool
-	dry_run      []string
+	release_name          string
+	chart                 string
+	version               string
+	ns                    string
+	wait                  bool
+	timeout               []int64
+	values                []uint8
+	env                   HelmEnv
+	reset_values          bool
+	reuse_values          bool
+	dry_run               []string
+	id                    uint64
+	install               bool
+	create_namespace      bool
+	atomic                bool
+	cleanup_on_fail       bool
+	wait_for_jobs         bool
+	max_history           int64
+	values_files          []string
+	set_values            []string
+	set_string_values     []string
+	set_json_values       []string
+	set_file_values       []string
+	set_literal_values    []string
+	events                bool
+	lock_timeout          []int64
+	recover_pending       bool
+	recover_policy        string
+	recover_pending_after int64
+	dependency_update     bool
+	cert_file             string
+	key_file              string
+	ca_file               string
+	insecure              bool
+	plain_http            bool
+	username              string
+	password              string
+	pass_credentials_all  bool
+	repo_url              string
+	keyring               string
+	verify                bool
 }
 
 func newUpgradeRequest(p C.UpgradeRequestRef) UpgradeRequest {
 	return UpgradeRequest{
-		release_name: newString(p.release_name),
-		chart:        newString(p.chart),
-		version:      newString(p.version),
-		ns:           newString(p.ns),
-		wait:         newC_bool(p.wait),
-		timeout:      new_list_mapper_primitive(newC_int64_t)(p.timeout),
-		values:       new_list_mapper_primitive(newC_uint8_t)(p.values),
-		env:          newHelmEnv(p.env),
-		reset_values: newC_bool(p.reset_values),
-		reuse_values: newC_bool(p.reuse_values),
-		dry_run:      new_list_mapper(newString)(p.dry_run),
+		release_name:          newString(p.release_name),
+		chart:                 newString(p.chart),
+		version:               newString(p.version),
+		ns:                    newString(p.ns),
+		wait:                  newC_bool(p.wait),
+		timeout:               new_list_mapper_primitive(newC_int64_t)(p.timeout),
+		values:                new_list_mapper_primitive(newC_uint8_t)(p.values),
+		env:                   newHelmEnv(p.env),
+		reset_values:          newC_bool(p.reset_values),
+		reuse_values:          newC_bool(p.reuse_values),
+		dry_run:               new_list_mapper(newString)(p.dry_run),
+		id:                    newC_uint64_t(p.id),
+		install:               newC_bool(p.install),
+		create_namespace:      newC_bool(p.create_namespace),
+		atomic:                newC_bool(p.atomic),
+		cleanup_on_fail:       newC_bool(p.cleanup_on_fail),
+		wait_for_jobs:         newC_bool(p.wait_for_jobs),
+		max_history:           newC_int64_t(p.max_history),
+		values_files:          new_list_mapper(newString)(p.values_files),
+		set_values:            new_list_mapper(newString)(p.set_values),
+		set_string_values:     new_list_mapper(newString)(p.set_string_values),
+		set_json_values:       new_list_mapper(newString)(p.set_json_values),
+		set_file_values:       new_list_mapper(newString)(p.set_file_values),
+		set_literal_values:    new_list_mapper(newString)(p.set_literal_values),
+		events:                newC_bool(p.events),
+		lock_timeout:          new_list_mapper_primitive(newC_int64_t)(p.lock_timeout),
+		recover_pending:       newC_bool(p.recover_pending),
+		recover_policy:        newString(p.recover_policy),
+		recover_pending_after: newC_int64_t(p.recover_pending_after),
+		dependency_update:     newC_bool(p.dependency_update),
+		cert_file:             newString(p.cert_file),
+		key_file:              newString(p.key_file),
+		ca_file:               newString(p.ca_file),
+		insecure:              newC_bool(p.insecure),
+		plain_http:            newC_bool(p.plain_http),
+		username:              newString(p.username),
+		password:              newString(p.password),
+		pass_credentials_all:  newC_bool(p.pass_credentials_all),
+		repo_url:              newString(p.repo_url),
+		keyring:               newString(p.keyring),
+		verify:                newC_bool(p.verify),
 	}
 }
 func ownUpgradeRequest(p C.UpgradeRequestRef) UpgradeRequest {
 	return UpgradeRequest{
-		release_name: ownString(p.release_name),
-		chart:        ownString(p.chart),
-		version:      ownString(p.version),
-		ns:           ownString(p.ns),
-		wait:         newC_bool(p.wait),
-		timeout:      new_list_mapper(newC_int64_t)(p.timeout),
-		values:       new_list_mapper(newC_uint8_t)(p.values),
-		env:          ownHelmEnv(p.env),
-		reset_values: newC_bool(p.reset_values),
-		reuse_values: newC_bool(p.reuse_values),
-		dry_run:      new_list_mapper(ownString)(p.dry_run),
+		release_name:          ownString(p.release_name),
+		chart:                 ownString(p.chart),
+		version:               ownString(p.version),
+		ns:                    ownString(p.ns),
+		wait:                  newC_bool(p.wait),
+		timeout:               new_list_mapper(newC_int64_t)(p.timeout),
+		values:                new_list_mapper(newC_uint8_t)(p.values),
+		env:                   ownHelmEnv(p.env),
+		reset_values:          newC_bool(p.reset_values),
+		reuse_values:          newC_bool(p.reuse_values),
+		dry_run:               new_list_mapper(ownString)(p.dry_run),
+		id:                    newC_uint64_t(p.id),
+		install:               newC_bool(p.install),
+		create_namespace:      newC_bool(p.create_namespace),
+		atomic:                newC_bool(p.atomic),
+		cleanup_on_fail:       newC_bool(p.cleanup_on_fail),
+		wait_for_jobs:         newC_bool(p.wait_for_jobs),
+		max_history:           newC_int64_t(p.max_history),
+		values_files:          new_list_mapper(ownString)(p.values_files),
+		set_values:            new_list_mapper(ownString)(p.set_values),
+		set_string_values:     new_list_mapper(ownString)(p.set_string_values),
+		set_json_values:       new_list_mapper(ownString)(p.set_json_values),
+		set_file_values:       new_list_mapper(ownString)(p.set_file_values),
+		set_literal_values:    new_list_mapper(ownString)(p.set_literal_values),
+		events:                newC_bool(p.events),
+		lock_timeout:          new_list_mapper(newC_int64_t)(p.lock_timeout),
+		recover_pending:       newC_bool(p.recover_pending),
+		recover_policy:        ownString(p.recover_policy),
+		recover_pending_after: newC_int64_t(p.recover_pending_after),
+		dependency_update:     newC_bool(p.dependency_update),
+		cert_file:             ownString(p.cert_file),
+		key_file:              ownString(p.key_file),
+		ca_file:               ownString(p.ca_file),
+		insecure:              newC_bool(p.insecure),
+		plain_http:            newC_bool(p.plain_http),
+		username:              ownString(p.username),
+		password:              ownString(p.password),
+		pass_credentials_all:  newC_bool(p.pass_credentials_all),
+		repo_url:              ownString(p.repo_url),
+		keyring:               ownString(p.keyring),
+		verify:                newC_bool(p.verify),
 	}
 }
 func cntUpgradeRequest(s *UpgradeRequest, cnt *uint) [0]C.UpgradeRequestRef {
 	cntHelmEnv(&s.env, cnt)
 	cnt_list_mapper(cntString)(&s.dry_run, cnt)
+	cnt_list_mapper(cntString)(&s.values_files, cnt)
+	cnt_list_mapper(cntString)(&s.set_values, cnt)
+	cnt_list_mapper(cntString)(&s.set_string_values, cnt)
+	cnt_list_mapper(cntString)(&s.set_json_values, cnt)
+	cnt_list_mapper(cntString)(&s.set_file_values, cnt)
+	cnt_list_mapper(cntString)(&s.set_literal_values, cnt)
 	return [0]C.UpgradeRequestRef{}
 }
 func refUpgradeRequest(p *UpgradeRequest, buffer *[]byte) C.UpgradeRequestRef {
 	return C.UpgradeRequestRef{
-		release_name: refString(&p.release_name, buffer),
-		chart:        refString(&p.chart, buffer),
-		version:      refString(&p.version, buffer),
-		ns:           refString(&p.ns, buffer),
-		wait:         refC_bool(&p.wait, buffer),
-		timeout:      ref_list_mapper_primitive(refC_int64_t)(&p.timeout, buffer),
-		values:       ref_list_mapper_primitive(refC_uint8_t)(&p.values, buffer),
-		env:          refHelmEnv(&p.env, buffer),
-		reset_values: refC_bool(&p.reset_values, buffer),
-		reuse_values: refC_bool(&p.reuse_values, buffer),
-		dry_run:      ref_list_mapper(refString)(&p.dry_run, buffer),
+		release_name:          refString(&p.release_name, buffer),
+		chart:                 refString(&p.chart, buffer),
+		version:               refString(&p.version, buffer),
+		ns:                    refString(&p.ns, buffer),
+		wait:                  refC_bool(&p.wait, buffer),
+		timeout:               ref_list_mapper_primitive(refC_int64_t)(&p.timeout, buffer),
+		values:                ref_list_mapper_primitive(refC_uint8_t)(&p.values, buffer),
+		env:                   refHelmEnv(&p.env, buffer),
+		reset_values:          refC_bool(&p.reset_values, buffer),
+		reuse_values:          refC_bool(&p.reuse_values, buffer),
+		dry_run:               ref_list_mapper(refString)(&p.dry_run, buffer),
+		id:                    refC_uint64_t(&p.id, buffer),
+		install:               refC_bool(&p.install, buffer),
+		create_namespace:      refC_bool(&p.create_namespace, buffer),
+		atomic:                refC_bool(&p.atomic, buffer),
+		cleanup_on_fail:       refC_bool(&p.cleanup_on_fail, buffer),
+		wait_for_jobs:         refC_bool(&p.wait_for_jobs, buffer),
+		max_history:           refC_int64_t(&p.max_history, buffer),
+		values_files:          ref_list_mapper(refString)(&p.values_files, buffer),
+		set_values:            ref_list_mapper(refString)(&p.set_values, buffer),
+		set_string_values:     ref_list_mapper(refString)(&p.set_string_values, buffer),
+		set_json_values:       ref_list_mapper(refString)(&p.set_json_values, buffer),
+		set_file_values:       ref_list_mapper(refString)(&p.set_file_values, buffer),
+		set_literal_values:    ref_list_mapper(refString)(&p.set_literal_values, buffer),
+		events:                refC_bool(&p.events, buffer),
+		lock_timeout:          ref_list_mapper_primitive(refC_int64_t)(&p.lock_timeout, buffer),
+		recover_pending:       refC_bool(&p.recover_pending, buffer),
+		recover_policy:        refString(&p.recover_policy, buffer),
+		recover_pending_after: refC_int64_t(&p.recover_pending_after, buffer),
+		dependency_update:     refC_bool(&p.dependency_update, buffer),
+		cert_file:             refString(&p.cert_file, buffer),
+		key_file:              refString(&p.key_file, buffer),
+		ca_file:               refString(&p.ca_file, buffer),
+		insecure:              refC_bool(&p.insecure, buffer),
+		plain_http:            refC_bool(&p.plain_http, buffer),
+		username:              refString(&p.username, buffer),
+		password:              refString(&p.password, buffer),
+		pass_credentials_all:  refC_bool(&p.pass_credentials_all, buffer),
+		repo_url:              refString(&p.repo_url, buffer),
+		keyring:               refString(&p.keyring, buffer),
+		verify:                refC_bool(&p.verify, buffer),
+	}
+}
+
+type TemplateRequest struct {
+	release_name         string
+	chart                string
+	version              string
+	ns                   string
+	values               []uint8
+	kube_version         []string
+	api_versions         []string
+	include_crds         bool
+	is_upgrade           bool
+	env                  HelmEnv
+	dependency_update    bool
+	cert_file            string
+	key_file             string
+	ca_file              string
+	insecure             bool
+	plain_http           bool
+	username             string
+	password             string
+	pass_credentials_all bool
+	repo_url             string
+	keyring              string
+	verify               bool
+}
+
+func newTemplateRequest(p C.TemplateRequestRef) TemplateRequest {
+	return TemplateRequest{
+		release_name:         newString(p.release_name),
+		chart:                newString(p.chart),
+		version:              newString(p.version),
+		ns:                   newString(p.ns),
+		values:               new_list_mapper_primitive(newC_uint8_t)(p.values),
+		kube_version:         new_list_mapper(newString)(p.kube_version),
+		api_versions:         new_list_mapper(newString)(p.api_versions),
+		include_crds:         newC_bool(p.include_crds),
+		is_upgrade:           newC_bool(p.is_upgrade),
+		env:                  newHelmEnv(p.env),
+		dependency_update:    newC_bool(p.dependency_update),
+		cert_file:            newString(p.cert_file),
+		key_file:             newString(p.key_file),
+		ca_file:              newString(p.ca_file),
+		insecure:             newC_bool(p.insecure),
+		plain_http:           newC_bool(p.plain_http),
+		username:             newString(p.username),
+		password:             newString(p.password),
+		pass_credentials_all: newC_bool(p.pass_credentials_all),
+		repo_url:             newString(p.repo_url),
+		keyring:              newString(p.keyring),
+		verify:               newC_bool(p.verify),
+	}
+}
+func ownTemplateRequest(p C.TemplateRequestRef) TemplateRequest {
+	return TemplateRequest{
+		release_name:         ownString(p.release_name),
+		chart:                ownString(p.chart),
+		version:              ownString(p.version),
+		ns:                   ownString(p.ns),
+		values:               new_list_mapper(newC_uint8_t)(p.values),
+		kube_version:         new_list_mapper(ownString)(p.kube_version),
+		api_versions:         new_list_mapper(ownString)(p.api_versions),
+		include_crds:         newC_bool(p.include_crds),
+		is_upgrade:           newC_bool(p.is_upgrade),
+		env:                  ownHelmEnv(p.env),
+		dependency_update:    newC_bool(p.dependency_update),
+		cert_file:            ownString(p.cert_file),
+		key_file:             ownString(p.key_file),
+		ca_file:              ownString(p.ca_file),
+		insecure:             newC_bool(p.insecure),
+		plain_http:           newC_bool(p.plain_http),
+		username:             ownString(p.username),
+		password:             ownString(p.password),
+		pass_credentials_all: newC_bool(p.pass_credentials_all),
+		repo_url:             ownString(p.repo_url),
+		keyring:              ownString(p.keyring),
+		verify:               newC_bool(p.verify),
+	}
+}
+func cntTemplateRequest(s *TemplateRequest, cnt *uint) [0]C.TemplateRequestRef {
+	cnt_list_mapper(cntString)(&s.kube_version, cnt)
+	cnt_list_mapper(cntString)(&s.api_versions, cnt)
+	cntHelmEnv(&s.env, cnt)
+	return [0]C.TemplateRequestRef{}
+}
+func refTemplateRequest(p *TemplateRequest, buffer *[]byte) C.TemplateRequestRef {
+	return C.TemplateRequestRef{
+		release_name:         refString(&p.release_name, buffer),
+		chart:                refString(&p.chart, buffer),
+		version:              refString(&p.version, buffer),
+		ns:                   refString(&p.ns, buffer),
+		values:               ref_list_mapper_primitive(refC_uint8_t)(&p.values, buffer),
+		kube_version:         ref_list_mapper(refString)(&p.kube_version, buffer),
+		api_versions:         ref_list_mapper(refString)(&p.api_versions, buffer),
+		include_crds:         refC_bool(&p.include_crds, buffer),
+		is_upgrade:           refC_bool(&p.is_upgrade, buffer),
+		env:                  refHelmEnv(&p.env, buffer),
+		dependency_update:    refC_bool(&p.dependency_update, buffer),
+		cert_file:            refString(&p.cert_file, buffer),
+		key_file:             refString(&p.key_file, buffer),
+		ca_file:              refString(&p.ca_file, buffer),
+		insecure:             refC_bool(&p.insecure, buffer),
+		plain_http:           refC_bool(&p.plain_http, buffer),
+		username:             refString(&p.username, buffer),
+		password:             refString(&p.password, buffer),
+		pass_credentials_all: refC_bool(&p.pass_credentials_all, buffer),
+		repo_url:             refString(&p.repo_url, buffer),
+		keyring:              refString(&p.keyring, buffer),
+		verify:               refC_bool(&p.verify, buffer),
 	}
 }
 
@@ -553,6 +1466,23 @@ type HelmEnv struct {
 	kube_token                    []string
 	kube_ca_file                  []string
 	kube_insecure_skip_tls_verify bool
+	kube_api_server               []string
+	kube_as_user                  []string
+	kube_as_groups                []string
+	kube_tls_server_name          []string
+	burst_limit                   []int64
+	qps                           []float32
+	kube_config_data              []uint8
+	kube_ca_data                  []uint8
+	kube_client_cert_data         []uint8
+	kube_client_key_data          []uint8
+	kube_in_cluster               bool
+	debug                         bool
+	log_level                     []string
+	driver                        []string
+	driver_connection             []string
+	fake_kube_client              []string
+	client_cache_ttl              []int64
 }
 
 func newHelmEnv(p C.HelmEnvRef) HelmEnv {
@@ -562,6 +1492,23 @@ func newHelmEnv(p C.HelmEnvRef) HelmEnv {
 		kube_token:                    new_list_mapper(newString)(p.kube_token),
 		kube_ca_file:                  new_list_mapper(newString)(p.kube_ca_file),
 		kube_insecure_skip_tls_verify: newC_bool(p.kube_insecure_skip_tls_verify),
+		kube_api_server:               new_list_mapper(newString)(p.kube_api_server),
+		kube_as_user:                  new_list_mapper(newString)(p.kube_as_user),
+		kube_as_groups:                new_list_mapper(newString)(p.kube_as_groups),
+		kube_tls_server_name:          new_list_mapper(newString)(p.kube_tls_server_name),
+		burst_limit:                   new_list_mapper_primitive(newC_int64_t)(p.burst_limit),
+		qps:                           new_list_mapper_primitive(newC_float)(p.qps),
+		kube_config_data:              new_list_mapper_primitive(newC_uint8_t)(p.kube_config_data),
+		kube_ca_data:                  new_list_mapper_primitive(newC_uint8_t)(p.kube_ca_data),
+		kube_client_cert_data:         new_list_mapper_primitive(newC_uint8_t)(p.kube_client_cert_data),
+		kube_client_key_data:          new_list_mapper_primitive(newC_uint8_t)(p.kube_client_key_data),
+		kube_in_cluster:               newC_bool(p.kube_in_cluster),
+		debug:                         newC_bool(p.debug),
+		log_level:                     new_list_mapper(newString)(p.log_level),
+		driver:                        new_list_mapper(newString)(p.driver),
+		driver_connection:             new_list_mapper(newString)(p.driver_connection),
+		fake_kube_client:              new_list_mapper(newString)(p.fake_kube_client),
+		client_cache_ttl:              new_list_mapper_primitive(newC_int64_t)(p.client_cache_ttl),
 	}
 }
 func ownHelmEnv(p C.HelmEnvRef) HelmEnv {
@@ -571,6 +1518,23 @@ func ownHelmEnv(p C.HelmEnvRef) HelmEnv {
 		kube_token:                    new_list_mapper(ownString)(p.kube_token),
 		kube_ca_file:                  new_list_mapper(ownString)(p.kube_ca_file),
 		kube_insecure_skip_tls_verify: newC_bool(p.kube_insecure_skip_tls_verify),
+		kube_api_server:               new_list_mapper(ownString)(p.kube_api_server),
+		kube_as_user:                  new_list_mapper(ownString)(p.kube_as_user),
+		kube_as_groups:                new_list_mapper(ownString)(p.kube_as_groups),
+		kube_tls_server_name:          new_list_mapper(ownString)(p.kube_tls_server_name),
+		burst_limit:                   new_list_mapper(newC_int64_t)(p.burst_limit),
+		qps:                           new_list_mapper(newC_float)(p.qps),
+		kube_config_data:              new_list_mapper(newC_uint8_t)(p.kube_config_data),
+		kube_ca_data:                  new_list_mapper(newC_uint8_t)(p.kube_ca_data),
+		kube_client_cert_data:         new_list_mapper(newC_uint8_t)(p.kube_client_cert_data),
+		kube_client_key_data:          new_list_mapper(newC_uint8_t)(p.kube_client_key_data),
+		kube_in_cluster:               newC_bool(p.kube_in_cluster),
+		debug:                         newC_bool(p.debug),
+		log_level:                     new_list_mapper(ownString)(p.log_level),
+		driver:                        new_list_mapper(ownString)(p.driver),
+		driver_connection:             new_list_mapper(ownString)(p.driver_connection),
+		fake_kube_client:              new_list_mapper(ownString)(p.fake_kube_client),
+		client_cache_ttl:              new_list_mapper(newC_int64_t)(p.client_cache_ttl),
 	}
 }
 func cntHelmEnv(s *HelmEnv, cnt *uint) [0]C.HelmEnvRef {
@@ -578,6 +1542,14 @@ func cntHelmEnv(s *HelmEnv, cnt *uint) [0]C.HelmEnvRef {
 	cnt_list_mapper(cntString)(&s.kube_context, cnt)
 	cnt_list_mapper(cntString)(&s.kube_token, cnt)
 	cnt_list_mapper(cntString)(&s.kube_ca_file, cnt)
+	cnt_list_mapper(cntString)(&s.kube_api_server, cnt)
+	cnt_list_mapper(cntString)(&s.kube_as_user, cnt)
+	cnt_list_mapper(cntString)(&s.kube_as_groups, cnt)
+	cnt_list_mapper(cntString)(&s.kube_tls_server_name, cnt)
+	cnt_list_mapper(cntString)(&s.log_level, cnt)
+	cnt_list_mapper(cntString)(&s.driver, cnt)
+	cnt_list_mapper(cntString)(&s.driver_connection, cnt)
+	cnt_list_mapper(cntString)(&s.fake_kube_client, cnt)
 	return [0]C.HelmEnvRef{}
 }
 func refHelmEnv(p *HelmEnv, buffer *[]byte) C.HelmEnvRef {
@@ -587,62 +1559,253 @@ func refHelmEnv(p *HelmEnv, buffer *[]byte) C.HelmEnvRef {
 		kube_token:                    ref_list_mapper(refString)(&p.kube_token, buffer),
 		kube_ca_file:                  ref_list_mapper(refString)(&p.kube_ca_file, buffer),
 		kube_insecure_skip_tls_verify: refC_bool(&p.kube_insecure_skip_tls_verify, buffer),
+		kube_api_server:               ref_list_mapper(refString)(&p.kube_api_server, buffer),
+		kube_as_user:                  ref_list_mapper(refString)(&p.kube_as_user, buffer),
+		kube_as_groups:                ref_list_mapper(refString)(&p.kube_as_groups, buffer),
+		kube_tls_server_name:          ref_list_mapper(refString)(&p.kube_tls_server_name, buffer),
+		burst_limit:                   ref_list_mapper_primitive(refC_int64_t)(&p.burst_limit, buffer),
+		qps:                           ref_list_mapper_primitive(refC_float)(&p.qps, buffer),
+		kube_config_data:              ref_list_mapper_primitive(refC_uint8_t)(&p.kube_config_data, buffer),
+		kube_ca_data:                  ref_list_mapper_primitive(refC_uint8_t)(&p.kube_ca_data, buffer),
+		kube_client_cert_data:         ref_list_mapper_primitive(refC_uint8_t)(&p.kube_client_cert_data, buffer),
+		kube_client_key_data:          ref_list_mapper_primitive(refC_uint8_t)(&p.kube_client_key_data, buffer),
+		kube_in_cluster:               refC_bool(&p.kube_in_cluster, buffer),
+		debug:                         refC_bool(&p.debug, buffer),
+		log_level:                     ref_list_mapper(refString)(&p.log_level, buffer),
+		driver:                        ref_list_mapper(refString)(&p.driver, buffer),
+		driver_connection:             ref_list_mapper(refString)(&p.driver_connection, buffer),
+		fake_kube_client:              ref_list_mapper(refString)(&p.fake_kube_client, buffer),
+		client_cache_ttl:              ref_list_mapper_primitive(refC_int64_t)(&p.client_cache_ttl, buffer),
+	}
+}
+
+type ReleaseSummary struct {
+	name           string
+	namespace      string
+	revision       int64
+	status         string
+	chart_name     string
+	chart_version  string
+	app_version    string
+	first_deployed string
+	last_deployed  string
+	description    string
+	notes          string
+}
+
+func newReleaseSummary(p C.ReleaseSummaryRef) ReleaseSummary {
+	return ReleaseSummary{
+		name:           newString(p.name),
+		namespace:      newString(p.namespace),
+		revision:       newC_int64_t(p.revision),
+		status:         newString(p.status),
+		chart_name:     newString(p.chart_name),
+		chart_version:  newString(p.chart_version),
+		app_version:    newString(p.app_version),
+		first_deployed: newString(p.first_deployed),
+		last_deployed:  newString(p.last_deployed),
+		description:    newString(p.description),
+		notes:          newString(p.notes),
+	}
+}
+func ownReleaseSummary(p C.ReleaseSummaryRef) ReleaseSummary {
+	return ReleaseSummary{
+		name:           ownString(p.name),
+		namespace:      ownString(p.namespace),
+		revision:       newC_int64_t(p.revision),
+		status:         ownString(p.status),
+		chart_name:     ownString(p.chart_name),
+		chart_version:  ownString(p.chart_version),
+		app_version:    ownString(p.app_version),
+		first_deployed: ownString(p.first_deployed),
+		last_deployed:  ownString(p.last_deployed),
+		description:    ownString(p.description),
+		notes:          ownString(p.notes),
+	}
+}
+func cntReleaseSummary(s *ReleaseSummary, cnt *uint) [0]C.ReleaseSummaryRef {
+	_ = s
+	_ = cnt
+	return [0]C.ReleaseSummaryRef{}
+}
+func refReleaseSummary(p *ReleaseSummary, buffer *[]byte) C.ReleaseSummaryRef {
+	return C.ReleaseSummaryRef{
+		name:           refString(&p.name, buffer),
+		namespace:      refString(&p.namespace, buffer),
+		revision:       refC_int64_t(&p.revision, buffer),
+		status:         refString(&p.status, buffer),
+		chart_name:     refString(&p.chart_name, buffer),
+		chart_version:  refString(&p.chart_version, buffer),
+		app_version:    refString(&p.app_version, buffer),
+		first_deployed: refString(&p.first_deployed, buffer),
+		last_deployed:  refString(&p.last_deployed, buffer),
+		description:    refString(&p.description, buffer),
+		notes:          refString(&p.notes, buffer),
 	}
 }
 
 type InstallResponse struct {
-	err  []string
-	data string
+	err         []string
+	err_kind    string
+	logs        []string
+	data        string
+	cancelled   bool
+	uninstalled bool
+	revision    int64
+	release     []ReleaseSummary
+	lock_wait   int64
 }
 
 func newInstallResponse(p C.InstallResponseRef) InstallResponse {
 	return InstallResponse{
-		err:  new_list_mapper(newString)(p.err),
-		data: newString(p.data),
+		err:         new_list_mapper(newString)(p.err),
+		err_kind:    newString(p.err_kind),
+		logs:        new_list_mapper(newString)(p.logs),
+		data:        newString(p.data),
+		cancelled:   newC_bool(p.cancelled),
+		uninstalled: newC_bool(p.uninstalled),
+		revision:    newC_int64_t(p.revision),
+		release:     new_list_mapper(newReleaseSummary)(p.release),
+		lock_wait:   newC_int64_t(p.lock_wait),
 	}
 }
 func ownInstallResponse(p C.InstallResponseRef) InstallResponse {
 	return InstallResponse{
-		err:  new_list_mapper(ownString)(p.err),
-		data: ownString(p.data),
+		err:         new_list_mapper(ownString)(p.err),
+		err_kind:    ownString(p.err_kind),
+		logs:        new_list_mapper(ownString)(p.logs),
+		data:        ownString(p.data),
+		cancelled:   newC_bool(p.cancelled),
+		uninstalled: newC_bool(p.uninstalled),
+		revision:    newC_int64_t(p.revision),
+		release:     new_list_mapper(ownReleaseSummary)(p.release),
+		lock_wait:   newC_int64_t(p.lock_wait),
 	}
 }
 func cntInstallResponse(s *InstallResponse, cnt *uint) [0]C.InstallResponseRef {
 	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
+	cnt_list_mapper(cntReleaseSummary)(&s.release, cnt)
 	return [0]C.InstallResponseRef{}
 }
 func refInstallResponse(p *InstallResponse, buffer *[]byte) C.InstallResponseRef {
 	return C.InstallResponseRef{
-		err:  ref_list_mapper(refString)(&p.err, buffer),
-		data: refString(&p.data, buffer),
+		err:         ref_list_mapper(refString)(&p.err, buffer),
+		err_kind:    refString(&p.err_kind, buffer),
+		logs:        ref_list_mapper(refString)(&p.logs, buffer),
+		data:        refString(&p.data, buffer),
+		cancelled:   refC_bool(&p.cancelled, buffer),
+		uninstalled: refC_bool(&p.uninstalled, buffer),
+		revision:    refC_int64_t(&p.revision, buffer),
+		release:     ref_list_mapper(refReleaseSummary)(&p.release, buffer),
+		lock_wait:   refC_int64_t(&p.lock_wait, buffer),
+	}
+}
+
+type TemplateResponse struct {
+	err      []string
+	err_kind string
+	logs     []string
+	manifest string
+	notes    string
+	hooks    string
+}
+
+func newTemplateResponse(p C.TemplateResponseRef) TemplateResponse {
+	return TemplateResponse{
+		err:      new_list_mapper(newString)(p.err),
+		err_kind: newString(p.err_kind),
+		logs:     new_list_mapper(newString)(p.logs),
+		manifest: newString(p.manifest),
+		notes:    newString(p.notes),
+		hooks:    newString(p.hooks),
+	}
+}
+func ownTemplateResponse(p C.TemplateResponseRef) TemplateResponse {
+	return TemplateResponse{
+		err:      new_list_mapper(ownString)(p.err),
+		err_kind: ownString(p.err_kind),
+		logs:     new_list_mapper(ownString)(p.logs),
+		manifest: ownString(p.manifest),
+		notes:    ownString(p.notes),
+		hooks:    ownString(p.hooks),
+	}
+}
+func cntTemplateResponse(s *TemplateResponse, cnt *uint) [0]C.TemplateResponseRef {
+	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
+	return [0]C.TemplateResponseRef{}
+}
+func refTemplateResponse(p *TemplateResponse, buffer *[]byte) C.TemplateResponseRef {
+	return C.TemplateResponseRef{
+		err:      ref_list_mapper(refString)(&p.err, buffer),
+		err_kind: refString(&p.err_kind, buffer),
+		logs:     ref_list_mapper(refString)(&p.logs, buffer),
+		manifest: refString(&p.manifest, buffer),
+		notes:    refString(&p.notes, buffer),
+		hooks:    refString(&p.hooks, buffer),
 	}
 }
 
 type UpgradeResponse struct {
-	err  []string
-	data string
+	err         []string
+	err_kind    string
+	logs        []string
+	data        string
+	cancelled   bool
+	rolled_back bool
+	uninstalled bool
+	revision    int64
+	release     []ReleaseSummary
+	lock_wait   int64
 }
 
 func newUpgradeResponse(p C.UpgradeResponseRef) UpgradeResponse {
 	return UpgradeResponse{
-		err:  new_list_mapper(newString)(p.err),
-		data: newString(p.data),
+		err:         new_list_mapper(newString)(p.err),
+		err_kind:    newString(p.err_kind),
+		logs:        new_list_mapper(newString)(p.logs),
+		data:        newString(p.data),
+		cancelled:   newC_bool(p.cancelled),
+		rolled_back: newC_bool(p.rolled_back),
+		uninstalled: newC_bool(p.uninstalled),
+		revision:    newC_int64_t(p.revision),
+		release:     new_list_mapper(newReleaseSummary)(p.release),
+		lock_wait:   newC_int64_t(p.lock_wait),
 	}
 }
 func ownUpgradeResponse(p C.UpgradeResponseRef) UpgradeResponse {
 	return UpgradeResponse{
-		err:  new_list_mapper(ownString)(p.err),
-		data: ownString(p.data),
+		err:         new_list_mapper(ownString)(p.err),
+		err_kind:    ownString(p.err_kind),
+		logs:        new_list_mapper(ownString)(p.logs),
+		data:        ownString(p.data),
+		cancelled:   newC_bool(p.cancelled),
+		rolled_back: newC_bool(p.rolled_back),
+		uninstalled: newC_bool(p.uninstalled),
+		revision:    newC_int64_t(p.revision),
+		release:     new_list_mapper(ownReleaseSummary)(p.release),
+		lock_wait:   newC_int64_t(p.lock_wait),
 	}
 }
 func cntUpgradeResponse(s *UpgradeResponse, cnt *uint) [0]C.UpgradeResponseRef {
 	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
+	cnt_list_mapper(cntReleaseSummary)(&s.release, cnt)
 	return [0]C.UpgradeResponseRef{}
 }
 func refUpgradeResponse(p *UpgradeResponse, buffer *[]byte) C.UpgradeResponseRef {
 	return C.UpgradeResponseRef{
-		err:  ref_list_mapper(refString)(&p.err, buffer),
-		data: refString(&p.data, buffer),
+		err:         ref_list_mapper(refString)(&p.err, buffer),
+		err_kind:    refString(&p.err_kind, buffer),
+		logs:        ref_list_mapper(refString)(&p.logs, buffer),
+		data:        refString(&p.data, buffer),
+		cancelled:   refC_bool(&p.cancelled, buffer),
+		rolled_back: refC_bool(&p.rolled_back, buffer),
+		uninstalled: refC_bool(&p.uninstalled, buffer),
+		revision:    refC_int64_t(&p.revision, buffer),
+		release:     ref_list_mapper(refReleaseSummary)(&p.release, buffer),
+		lock_wait:   refC_int64_t(&p.lock_wait, buffer),
 	}
 }
 
@@ -747,30 +1910,44 @@ func refListRequest(p *ListRequest, buffer *[]byte) C.ListRequestRef {
 }
 
 type ListResponse struct {
-	err  []string
-	data string
+	err      []string
+	err_kind string
+	logs     []string
+	data     string
+	releases []ReleaseSummary
 }
 
 func newListResponse(p C.ListResponseRef) ListResponse {
 	return ListResponse{
-		err:  new_list_mapper(newString)(p.err),
-		data: newString(p.data),
+		err:      new_list_mapper(newString)(p.err),
+		err_kind: newString(p.err_kind),
+		logs:     new_list_mapper(newString)(p.logs),
+		data:     newString(p.data),
+		releases: new_list_mapper(newReleaseSummary)(p.releases),
 	}
 }
 func ownListResponse(p C.ListResponseRef) ListResponse {
 	return ListResponse{
-		err:  new_list_mapper(ownString)(p.err),
-		data: ownString(p.data),
+		err:      new_list_mapper(ownString)(p.err),
+		err_kind: ownString(p.err_kind),
+		logs:     new_list_mapper(ownString)(p.logs),
+		data:     ownString(p.data),
+		releases: new_list_mapper(ownReleaseSummary)(p.releases),
 	}
 }
 func cntListResponse(s *ListResponse, cnt *uint) [0]C.ListResponseRef {
 	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
+	cnt_list_mapper(cntReleaseSummary)(&s.releases, cnt)
 	return [0]C.ListResponseRef{}
 }
 func refListResponse(p *ListResponse, buffer *[]byte) C.ListResponseRef {
 	return C.ListResponseRef{
-		err:  ref_list_mapper(refString)(&p.err, buffer),
-		data: refString(&p.data, buffer),
+		err:      ref_list_mapper(refString)(&p.err, buffer),
+		err_kind: refString(&p.err_kind, buffer),
+		logs:     ref_list_mapper(refString)(&p.logs, buffer),
+		data:     refString(&p.data, buffer),
+		releases: ref_list_mapper(refReleaseSummary)(&p.releases, buffer),
 	}
 }
 
@@ -820,30 +1997,39 @@ func refSearchRequest(p *SearchRequest, buffer *[]byte) C.SearchRequestRef {
 }
 
 type SearchResponse struct {
-	err  []string
-	data string
+	err      []string
+	err_kind string
+	logs     []string
+	data     string
 }
 
 func newSearchResponse(p C.SearchResponseRef) SearchResponse {
 	return SearchResponse{
-		err:  new_list_mapper(newString)(p.err),
-		data: newString(p.data),
+		err:      new_list_mapper(newString)(p.err),
+		err_kind: newString(p.err_kind),
+		logs:     new_list_mapper(newString)(p.logs),
+		data:     newString(p.data),
 	}
 }
 func ownSearchResponse(p C.SearchResponseRef) SearchResponse {
 	return SearchResponse{
-		err:  new_list_mapper(ownString)(p.err),
-		data: ownString(p.data),
+		err:      new_list_mapper(ownString)(p.err),
+		err_kind: ownString(p.err_kind),
+		logs:     new_list_mapper(ownString)(p.logs),
+		data:     ownString(p.data),
 	}
 }
 func cntSearchResponse(s *SearchResponse, cnt *uint) [0]C.SearchResponseRef {
 	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
 	return [0]C.SearchResponseRef{}
 }
 func refSearchResponse(p *SearchResponse, buffer *[]byte) C.SearchResponseRef {
 	return C.SearchResponseRef{
-		err:  ref_list_mapper(refString)(&p.err, buffer),
-		data: refString(&p.data, buffer),
+		err:      ref_list_mapper(refString)(&p.err, buffer),
+		err_kind: refString(&p.err_kind, buffer),
+		logs:     ref_list_mapper(refString)(&p.logs, buffer),
+		data:     refString(&p.data, buffer),
 	}
 }
 
@@ -920,26 +2106,279 @@ func refAddRequest(p *AddRequest, buffer *[]byte) C.AddRequestRef {
 }
 
 type AddResponse struct {
-	err []string
+	err      []string
+	err_kind string
+	logs     []string
 }
 
 func newAddResponse(p C.AddResponseRef) AddResponse {
 	return AddResponse{
-		err: new_list_mapper(newString)(p.err),
+		err:      new_list_mapper(newString)(p.err),
+		err_kind: newString(p.err_kind),
+		logs:     new_list_mapper(newString)(p.logs),
 	}
 }
 func ownAddResponse(p C.AddResponseRef) AddResponse {
 	return AddResponse{
-		err: new_list_mapper(ownString)(p.err),
+		err:      new_list_mapper(ownString)(p.err),
+		err_kind: ownString(p.err_kind),
+		logs:     new_list_mapper(ownString)(p.logs),
 	}
 }
 func cntAddResponse(s *AddResponse, cnt *uint) [0]C.AddResponseRef {
 	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
 	return [0]C.AddResponseRef{}
 }
 func refAddResponse(p *AddResponse, buffer *[]byte) C.AddResponseRef {
 	return C.AddResponseRef{
-		err: ref_list_mapper(refString)(&p.err, buffer),
+		err:      ref_list_mapper(refString)(&p.err, buffer),
+		err_kind: refString(&p.err_kind, buffer),
+		logs:     ref_list_mapper(refString)(&p.logs, buffer),
+	}
+}
+
+type RecoverRequest struct {
+	ns           string
+	release_name string
+	policy       string
+	min_age      int64
+	wait         bool
+	timeout      []int64
+	id           uint64
+	lock_timeout []int64
+	env          HelmEnv
+}
+
+func newRecoverRequest(p C.RecoverRequestRef) RecoverRequest {
+	return RecoverRequest{
+		ns:           newString(p.ns),
+		release_name: newString(p.release_name),
+		policy:       newString(p.policy),
+		min_age:      newC_int64_t(p.min_age),
+		wait:         newC_bool(p.wait),
+		timeout:      new_list_mapper_primitive(newC_int64_t)(p.timeout),
+		id:           newC_uint64_t(p.id),
+		lock_timeout: new_list_mapper_primitive(newC_int64_t)(p.lock_timeout),
+		env:          newHelmEnv(p.env),
+	}
+}
+func ownRecoverRequest(p C.RecoverRequestRef) RecoverRequest {
+	return RecoverRequest{
+		ns:           ownString(p.ns),
+		release_name: ownString(p.release_name),
+		policy:       ownString(p.policy),
+		min_age:      newC_int64_t(p.min_age),
+		wait:         newC_bool(p.wait),
+		timeout:      new_list_mapper(newC_int64_t)(p.timeout),
+		id:           newC_uint64_t(p.id),
+		lock_timeout: new_list_mapper(newC_int64_t)(p.lock_timeout),
+		env:          ownHelmEnv(p.env),
+	}
+}
+func cntRecoverRequest(s *RecoverRequest, cnt *uint) [0]C.RecoverRequestRef {
+	cntHelmEnv(&s.env, cnt)
+	return [0]C.RecoverRequestRef{}
+}
+func refRecoverRequest(p *RecoverRequest, buffer *[]byte) C.RecoverRequestRef {
+	return C.RecoverRequestRef{
+		ns:           refString(&p.ns, buffer),
+		release_name: refString(&p.release_name, buffer),
+		policy:       refString(&p.policy, buffer),
+		min_age:      refC_int64_t(&p.min_age, buffer),
+		wait:         refC_bool(&p.wait, buffer),
+		timeout:      ref_list_mapper_primitive(refC_int64_t)(&p.timeout, buffer),
+		id:           refC_uint64_t(&p.id, buffer),
+		lock_timeout: ref_list_mapper_primitive(refC_int64_t)(&p.lock_timeout, buffer),
+		env:          refHelmEnv(&p.env, buffer),
+	}
+}
+
+type RecoverResponse struct {
+	err       []string
+	err_kind  string
+	logs      []string
+	data      string
+	cancelled bool
+	status    string
+	recovered bool
+	release   []ReleaseSummary
+	lock_wait int64
+}
+
+func newRecoverResponse(p C.RecoverResponseRef) RecoverResponse {
+	return RecoverResponse{
+		err:       new_list_mapper(newString)(p.err),
+		err_kind:  newString(p.err_kind),
+		logs:      new_list_mapper(newString)(p.logs),
+		data:      newString(p.data),
+		cancelled: newC_bool(p.cancelled),
+		status:    newString(p.status),
+		recovered: newC_bool(p.recovered),
+		release:   new_list_mapper(newReleaseSummary)(p.release),
+		lock_wait: newC_int64_t(p.lock_wait),
+	}
+}
+func ownRecoverResponse(p C.RecoverResponseRef) RecoverResponse {
+	return RecoverResponse{
+		err:       new_list_mapper(ownString)(p.err),
+		err_kind:  ownString(p.err_kind),
+		logs:      new_list_mapper(ownString)(p.logs),
+		data:      ownString(p.data),
+		cancelled: newC_bool(p.cancelled),
+		status:    ownString(p.status),
+		recovered: newC_bool(p.recovered),
+		release:   new_list_mapper(ownReleaseSummary)(p.release),
+		lock_wait: newC_int64_t(p.lock_wait),
+	}
+}
+func cntRecoverResponse(s *RecoverResponse, cnt *uint) [0]C.RecoverResponseRef {
+	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
+	cnt_list_mapper(cntReleaseSummary)(&s.release, cnt)
+	return [0]C.RecoverResponseRef{}
+}
+func refRecoverResponse(p *RecoverResponse, buffer *[]byte) C.RecoverResponseRef {
+	return C.RecoverResponseRef{
+		err:       ref_list_mapper(refString)(&p.err, buffer),
+		err_kind:  refString(&p.err_kind, buffer),
+		logs:      ref_list_mapper(refString)(&p.logs, buffer),
+		data:      refString(&p.data, buffer),
+		cancelled: refC_bool(&p.cancelled, buffer),
+		status:    refString(&p.status, buffer),
+		recovered: refC_bool(&p.recovered, buffer),
+		release:   ref_list_mapper(refReleaseSummary)(&p.release, buffer),
+		lock_wait: refC_int64_t(&p.lock_wait, buffer),
+	}
+}
+
+type ListPageRequest struct {
+	ns             string
+	env            HelmEnv
+	all            bool
+	all_namespaces bool
+	state_mask     uint64
+	deployed       bool
+	failed         bool
+	pending        bool
+	uninstalled    bool
+	uninstalling   bool
+	superseded     bool
+	filter         string
+	selector       string
+	by_date        bool
+	sort_reverse   bool
+	page_size      int64
+	cursor         string
+}
+
+func newListPageRequest(p C.ListPageRequestRef) ListPageRequest {
+	return ListPageRequest{
+		ns:             newString(p.ns),
+		env:            newHelmEnv(p.env),
+		all:            newC_bool(p.all),
+		all_namespaces: newC_bool(p.all_namespaces),
+		state_mask:     newC_uint64_t(p.state_mask),
+		deployed:       newC_bool(p.deployed),
+		failed:         newC_bool(p.failed),
+		pending:        newC_bool(p.pending),
+		uninstalled:    newC_bool(p.uninstalled),
+		uninstalling:   newC_bool(p.uninstalling),
+		superseded:     newC_bool(p.superseded),
+		filter:         newString(p.filter),
+		selector:       newString(p.selector),
+		by_date:        newC_bool(p.by_date),
+		sort_reverse:   newC_bool(p.sort_reverse),
+		page_size:      newC_int64_t(p.page_size),
+		cursor:         newString(p.cursor),
+	}
+}
+func ownListPageRequest(p C.ListPageRequestRef) ListPageRequest {
+	return ListPageRequest{
+		ns:             ownString(p.ns),
+		env:            ownHelmEnv(p.env),
+		all:            newC_bool(p.all),
+		all_namespaces: newC_bool(p.all_namespaces),
+		state_mask:     newC_uint64_t(p.state_mask),
+		deployed:       newC_bool(p.deployed),
+		failed:         newC_bool(p.failed),
+		pending:        newC_bool(p.pending),
+		uninstalled:    newC_bool(p.uninstalled),
+		uninstalling:   newC_bool(p.uninstalling),
+		superseded:     newC_bool(p.superseded),
+		filter:         ownString(p.filter),
+		selector:       ownString(p.selector),
+		by_date:        newC_bool(p.by_date),
+		sort_reverse:   newC_bool(p.sort_reverse),
+		page_size:      newC_int64_t(p.page_size),
+		cursor:         ownString(p.cursor),
+	}
+}
+func cntListPageRequest(s *ListPageRequest, cnt *uint) [0]C.ListPageRequestRef {
+	cntHelmEnv(&s.env, cnt)
+	return [0]C.ListPageRequestRef{}
+}
+func refListPageRequest(p *ListPageRequest, buffer *[]byte) C.ListPageRequestRef {
+	return C.ListPageRequestRef{
+		ns:             refString(&p.ns, buffer),
+		env:            refHelmEnv(&p.env, buffer),
+		all:            refC_bool(&p.all, buffer),
+		all_namespaces: refC_bool(&p.all_namespaces, buffer),
+		state_mask:     refC_uint64_t(&p.state_mask, buffer),
+		deployed:       refC_bool(&p.deployed, buffer),
+		failed:         refC_bool(&p.failed, buffer),
+		pending:        refC_bool(&p.pending, buffer),
+		uninstalled:    refC_bool(&p.uninstalled, buffer),
+		uninstalling:   refC_bool(&p.uninstalling, buffer),
+		superseded:     refC_bool(&p.superseded, buffer),
+		filter:         refString(&p.filter, buffer),
+		selector:       refString(&p.selector, buffer),
+		by_date:        refC_bool(&p.by_date, buffer),
+		sort_reverse:   refC_bool(&p.sort_reverse, buffer),
+		page_size:      refC_int64_t(&p.page_size, buffer),
+		cursor:         refString(&p.cursor, buffer),
+	}
+}
+
+type ListPageResponse struct {
+	err         []string
+	err_kind    string
+	logs        []string
+	releases    []ReleaseSummary
+	next_cursor string
+}
+
+func newListPageResponse(p C.ListPageResponseRef) ListPageResponse {
+	return ListPageResponse{
+		err:         new_list_mapper(newString)(p.err),
+		err_kind:    newString(p.err_kind),
+		logs:        new_list_mapper(newString)(p.logs),
+		releases:    new_list_mapper(newReleaseSummary)(p.releases),
+		next_cursor: newString(p.next_cursor),
+	}
+}
+func ownListPageResponse(p C.ListPageResponseRef) ListPageResponse {
+	return ListPageResponse{
+		err:         new_list_mapper(ownString)(p.err),
+		err_kind:    ownString(p.err_kind),
+		logs:        new_list_mapper(ownString)(p.logs),
+		releases:    new_list_mapper(ownReleaseSummary)(p.releases),
+		next_cursor: ownString(p.next_cursor),
+	}
+}
+func cntListPageResponse(s *ListPageResponse, cnt *uint) [0]C.ListPageResponseRef {
+	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
+	cnt_list_mapper(cntReleaseSummary)(&s.releases, cnt)
+	return [0]C.ListPageResponseRef{}
+}
+func refListPageResponse(p *ListPageResponse, buffer *[]byte) C.ListPageResponseRef {
+	return C.ListPageResponseRef{
+		err:         ref_list_mapper(refString)(&p.err, buffer),
+		err_kind:    refString(&p.err_kind, buffer),
+		logs:        ref_list_mapper(refString)(&p.logs, buffer),
+		releases:    ref_list_mapper(refReleaseSummary)(&p.releases, buffer),
+		next_cursor: refString(&p.next_cursor, buffer),
 	}
 }
 
@@ -955,6 +2394,9 @@ type UninstallRequest struct {
 	timeout              []int64
 	description          string
 	env                  HelmEnv
+	id                   uint64
+	events               bool
+	lock_timeout         []int64
 }
 
 func newUninstallRequest(p C.UninstallRequestRef) UninstallRequest {
@@ -970,6 +2412,9 @@ func newUninstallRequest(p C.UninstallRequestRef) UninstallRequest {
 		timeout:              new_list_mapper_primitive(newC_int64_t)(p.timeout),
 		description:          newString(p.description),
 		env:                  newHelmEnv(p.env),
+		id:                   newC_uint64_t(p.id),
+		events:               newC_bool(p.events),
+		lock_timeout:         new_list_mapper_primitive(newC_int64_t)(p.lock_timeout),
 	}
 }
 func ownUninstallRequest(p C.UninstallRequestRef) UninstallRequest {
@@ -985,6 +2430,9 @@ func ownUninstallRequest(p C.UninstallRequestRef) UninstallRequest {
 		timeout:              new_list_mapper(newC_int64_t)(p.timeout),
 		description:          ownString(p.description),
 		env:                  ownHelmEnv(p.env),
+		id:                   newC_uint64_t(p.id),
+		events:               newC_bool(p.events),
+		lock_timeout:         new_list_mapper(newC_int64_t)(p.lock_timeout),
 	}
 }
 func cntUninstallRequest(s *UninstallRequest, cnt *uint) [0]C.UninstallRequestRef {
@@ -1004,34 +2452,462 @@ func refUninstallRequest(p *UninstallRequest, buffer *[]byte) C.UninstallRequest
 		timeout:              ref_list_mapper_primitive(refC_int64_t)(&p.timeout, buffer),
 		description:          refString(&p.description, buffer),
 		env:                  refHelmEnv(&p.env, buffer),
+		id:                   refC_uint64_t(&p.id, buffer),
+		events:               refC_bool(&p.events, buffer),
+		lock_timeout:         ref_list_mapper_primitive(refC_int64_t)(&p.lock_timeout, buffer),
 	}
 }
 
 type UninstallResponse struct {
-	err  []string
-	data string
+	err       []string
+	err_kind  string
+	logs      []string
+	data      string
+	cancelled bool
+	release   []ReleaseSummary
+	lock_wait int64
 }
 
 func newUninstallResponse(p C.UninstallResponseRef) UninstallResponse {
 	return UninstallResponse{
-		err:  new_list_mapper(newString)(p.err),
-		data: newString(p.data),
+		err:       new_list_mapper(newString)(p.err),
+		err_kind:  newString(p.err_kind),
+		logs:      new_list_mapper(newString)(p.logs),
+		data:      newString(p.data),
+		cancelled: newC_bool(p.cancelled),
+		release:   new_list_mapper(newReleaseSummary)(p.release),
+		lock_wait: newC_int64_t(p.lock_wait),
 	}
 }
 func ownUninstallResponse(p C.UninstallResponseRef) UninstallResponse {
 	return UninstallResponse{
-		err:  new_list_mapper(ownString)(p.err),
-		data: ownString(p.data),
+		err:       new_list_mapper(ownString)(p.err),
+		err_kind:  ownString(p.err_kind),
+		logs:      new_list_mapper(ownString)(p.logs),
+		data:      ownString(p.data),
+		cancelled: newC_bool(p.cancelled),
+		release:   new_list_mapper(ownReleaseSummary)(p.release),
+		lock_wait: newC_int64_t(p.lock_wait),
 	}
 }
 func cntUninstallResponse(s *UninstallResponse, cnt *uint) [0]C.UninstallResponseRef {
 	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
+	cnt_list_mapper(cntReleaseSummary)(&s.release, cnt)
 	return [0]C.UninstallResponseRef{}
 }
 func refUninstallResponse(p *UninstallResponse, buffer *[]byte) C.UninstallResponseRef {
 	return C.UninstallResponseRef{
-		err:  ref_list_mapper(refString)(&p.err, buffer),
-		data: refString(&p.data, buffer),
+		err:       ref_list_mapper(refString)(&p.err, buffer),
+		err_kind:  refString(&p.err_kind, buffer),
+		logs:      ref_list_mapper(refString)(&p.logs, buffer),
+		data:      refString(&p.data, buffer),
+		cancelled: refC_bool(&p.cancelled, buffer),
+		release:   ref_list_mapper(refReleaseSummary)(&p.release, buffer),
+		lock_wait: refC_int64_t(&p.lock_wait, buffer),
+	}
+}
+
+type StatusRequest struct {
+	ns             string
+	release_name   string
+	revision       int64
+	show_resources bool
+	env            HelmEnv
+}
+
+func newStatusRequest(p C.StatusRequestRef) StatusRequest {
+	return StatusRequest{
+		ns:             newString(p.ns),
+		release_name:   newString(p.release_name),
+		revision:       newC_int64_t(p.revision),
+		show_resources: newC_bool(p.show_resources),
+		env:            newHelmEnv(p.env),
+	}
+}
+func ownStatusRequest(p C.StatusRequestRef) StatusRequest {
+	return StatusRequest{
+		ns:             ownString(p.ns),
+		release_name:   ownString(p.release_name),
+		revision:       newC_int64_t(p.revision),
+		show_resources: newC_bool(p.show_resources),
+		env:            ownHelmEnv(p.env),
+	}
+}
+func cntStatusRequest(s *StatusRequest, cnt *uint) [0]C.StatusRequestRef {
+	cntHelmEnv(&s.env, cnt)
+	return [0]C.StatusRequestRef{}
+}
+func refStatusRequest(p *StatusRequest, buffer *[]byte) C.StatusRequestRef {
+	return C.StatusRequestRef{
+		ns:             refString(&p.ns, buffer),
+		release_name:   refString(&p.release_name, buffer),
+		revision:       refC_int64_t(&p.revision, buffer),
+		show_resources: refC_bool(&p.show_resources, buffer),
+		env:            refHelmEnv(&p.env, buffer),
+	}
+}
+
+type ResourceReadiness struct {
+	kind      string
+	name      string
+	namespace string
+	ready     bool
+	reason    string
+}
+
+func newResourceReadiness(p C.ResourceReadinessRef) ResourceReadiness {
+	return ResourceReadiness{
+		kind:      newString(p.kind),
+		name:      newString(p.name),
+		namespace: newString(p.namespace),
+		ready:     newC_bool(p.ready),
+		reason:    newString(p.reason),
+	}
+}
+func ownResourceReadiness(p C.ResourceReadinessRef) ResourceReadiness {
+	return ResourceReadiness{
+		kind:      ownString(p.kind),
+		name:      ownString(p.name),
+		namespace: ownString(p.namespace),
+		ready:     newC_bool(p.ready),
+		reason:    ownString(p.reason),
+	}
+}
+func cntResourceReadiness(s *ResourceReadiness, cnt *uint) [0]C.ResourceReadinessRef {
+	_ = s
+	_ = cnt
+	return [0]C.ResourceReadinessRef{}
+}
+func refResourceReadiness(p *ResourceReadiness, buffer *[]byte) C.ResourceReadinessRef {
+	return C.ResourceReadinessRef{
+		kind:      refString(&p.kind, buffer),
+		name:      refString(&p.name, buffer),
+		namespace: refString(&p.namespace, buffer),
+		ready:     refC_bool(&p.ready, buffer),
+		reason:    refString(&p.reason, buffer),
+	}
+}
+
+type StatusResponse struct {
+	err       []string
+	err_kind  string
+	logs      []string
+	data      string
+	resources []ResourceReadiness
+}
+
+func newStatusResponse(p C.StatusResponseRef) StatusResponse {
+	return StatusResponse{
+		err:       new_list_mapper(newString)(p.err),
+		err_kind:  newString(p.err_kind),
+		logs:      new_list_mapper(newString)(p.logs),
+		data:      newString(p.data),
+		resources: new_list_mapper(newResourceReadiness)(p.resources),
+	}
+}
+func ownStatusResponse(p C.StatusResponseRef) StatusResponse {
+	return StatusResponse{
+		err:       new_list_mapper(ownString)(p.err),
+		err_kind:  ownString(p.err_kind),
+		logs:      new_list_mapper(ownString)(p.logs),
+		data:      ownString(p.data),
+		resources: new_list_mapper(ownResourceReadiness)(p.resources),
+	}
+}
+func cntStatusResponse(s *StatusResponse, cnt *uint) [0]C.StatusResponseRef {
+	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
+	cnt_list_mapper(cntResourceReadiness)(&s.resources, cnt)
+	return [0]C.StatusResponseRef{}
+}
+func refStatusResponse(p *StatusResponse, buffer *[]byte) C.StatusResponseRef {
+	return C.StatusResponseRef{
+		err:       ref_list_mapper(refString)(&p.err, buffer),
+		err_kind:  refString(&p.err_kind, buffer),
+		logs:      ref_list_mapper(refString)(&p.logs, buffer),
+		data:      refString(&p.data, buffer),
+		resources: ref_list_mapper(refResourceReadiness)(&p.resources, buffer),
+	}
+}
+
+type GetRequest struct {
+	ns           string
+	release_name string
+	what         string
+	revision     int64
+	env          HelmEnv
+}
+
+func newGetRequest(p C.GetRequestRef) GetRequest {
+	return GetRequest{
+		ns:           newString(p.ns),
+		release_name: newString(p.release_name),
+		what:         newString(p.what),
+		revision:     newC_int64_t(p.revision),
+		env:          newHelmEnv(p.env),
+	}
+}
+func ownGetRequest(p C.GetRequestRef) GetRequest {
+	return GetRequest{
+		ns:           ownString(p.ns),
+		release_name: ownString(p.release_name),
+		what:         ownString(p.what),
+		revision:     newC_int64_t(p.revision),
+		env:          ownHelmEnv(p.env),
+	}
+}
+func cntGetRequest(s *GetRequest, cnt *uint) [0]C.GetRequestRef {
+	cntHelmEnv(&s.env, cnt)
+	return [0]C.GetRequestRef{}
+}
+func refGetRequest(p *GetRequest, buffer *[]byte) C.GetRequestRef {
+	return C.GetRequestRef{
+		ns:           refString(&p.ns, buffer),
+		release_name: refString(&p.release_name, buffer),
+		what:         refString(&p.what, buffer),
+		revision:     refC_int64_t(&p.revision, buffer),
+		env:          refHelmEnv(&p.env, buffer),
+	}
+}
+
+type GetResponse struct {
+	err      []string
+	err_kind string
+	logs     []string
+	json     []uint8
+	text     string
+}
+
+func newGetResponse(p C.GetResponseRef) GetResponse {
+	return GetResponse{
+		err:      new_list_mapper(newString)(p.err),
+		err_kind: newString(p.err_kind),
+		logs:     new_list_mapper(newString)(p.logs),
+		json:     new_list_mapper_primitive(newC_uint8_t)(p.json),
+		text:     newString(p.text),
+	}
+}
+func ownGetResponse(p C.GetResponseRef) GetResponse {
+	return GetResponse{
+		err:      new_list_mapper(ownString)(p.err),
+		err_kind: ownString(p.err_kind),
+		logs:     new_list_mapper(ownString)(p.logs),
+		json:     new_list_mapper(newC_uint8_t)(p.json),
+		text:     ownString(p.text),
+	}
+}
+func cntGetResponse(s *GetResponse, cnt *uint) [0]C.GetResponseRef {
+	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
+	return [0]C.GetResponseRef{}
+}
+func refGetResponse(p *GetResponse, buffer *[]byte) C.GetResponseRef {
+	return C.GetResponseRef{
+		err:      ref_list_mapper(refString)(&p.err, buffer),
+		err_kind: refString(&p.err_kind, buffer),
+		logs:     ref_list_mapper(refString)(&p.logs, buffer),
+		json:     ref_list_mapper_primitive(refC_uint8_t)(&p.json, buffer),
+		text:     refString(&p.text, buffer),
+	}
+}
+
+type HistoryRequest struct {
+	ns           string
+	release_name string
+	max          int64
+	env          HelmEnv
+}
+
+func newHistoryRequest(p C.HistoryRequestRef) HistoryRequest {
+	return HistoryRequest{
+		ns:           newString(p.ns),
+		release_name: newString(p.release_name),
+		max:          newC_int64_t(p.max),
+		env:          newHelmEnv(p.env),
+	}
+}
+func ownHistoryRequest(p C.HistoryRequestRef) HistoryRequest {
+	return HistoryRequest{
+		ns:           ownString(p.ns),
+		release_name: ownString(p.release_name),
+		max:          newC_int64_t(p.max),
+		env:          ownHelmEnv(p.env),
+	}
+}
+func cntHistoryRequest(s *HistoryRequest, cnt *uint) [0]C.HistoryRequestRef {
+	cntHelmEnv(&s.env, cnt)
+	return [0]C.HistoryRequestRef{}
+}
+func refHistoryRequest(p *HistoryRequest, buffer *[]byte) C.HistoryRequestRef {
+	return C.HistoryRequestRef{
+		ns:           refString(&p.ns, buffer),
+		release_name: refString(&p.release_name, buffer),
+		max:          refC_int64_t(&p.max, buffer),
+		env:          refHelmEnv(&p.env, buffer),
+	}
+}
+
+type HistoryResponse struct {
+	err      []string
+	err_kind string
+	logs     []string
+	data     string
+}
+
+func newHistoryResponse(p C.HistoryResponseRef) HistoryResponse {
+	return HistoryResponse{
+		err:      new_list_mapper(newString)(p.err),
+		err_kind: newString(p.err_kind),
+		logs:     new_list_mapper(newString)(p.logs),
+		data:     newString(p.data),
+	}
+}
+func ownHistoryResponse(p C.HistoryResponseRef) HistoryResponse {
+	return HistoryResponse{
+		err:      new_list_mapper(ownString)(p.err),
+		err_kind: ownString(p.err_kind),
+		logs:     new_list_mapper(ownString)(p.logs),
+		data:     ownString(p.data),
+	}
+}
+func cntHistoryResponse(s *HistoryResponse, cnt *uint) [0]C.HistoryResponseRef {
+	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
+	return [0]C.HistoryResponseRef{}
+}
+func refHistoryResponse(p *HistoryResponse, buffer *[]byte) C.HistoryResponseRef {
+	return C.HistoryResponseRef{
+		err:      ref_list_mapper(refString)(&p.err, buffer),
+		err_kind: refString(&p.err_kind, buffer),
+		logs:     ref_list_mapper(refString)(&p.logs, buffer),
+		data:     refString(&p.data, buffer),
+	}
+}
+
+type RollbackRequest struct {
+	ns              string
+	release_name    string
+	revision        int64
+	wait            bool
+	wait_for_jobs   bool
+	timeout         []int64
+	force           bool
+	recreate        bool
+	cleanup_on_fail bool
+	disable_hooks   bool
+	dry_run         bool
+	env             HelmEnv
+	id              uint64
+	lock_timeout    []int64
+}
+
+func newRollbackRequest(p C.RollbackRequestRef) RollbackRequest {
+	return RollbackRequest{
+		ns:              newString(p.ns),
+		release_name:    newString(p.release_name),
+		revision:        newC_int64_t(p.revision),
+		wait:            newC_bool(p.wait),
+		wait_for_jobs:   newC_bool(p.wait_for_jobs),
+		timeout:         new_list_mapper_primitive(newC_int64_t)(p.timeout),
+		force:           newC_bool(p.force),
+		recreate:        newC_bool(p.recreate),
+		cleanup_on_fail: newC_bool(p.cleanup_on_fail),
+		disable_hooks:   newC_bool(p.disable_hooks),
+		dry_run:         newC_bool(p.dry_run),
+		env:             newHelmEnv(p.env),
+		id:              newC_uint64_t(p.id),
+		lock_timeout:    new_list_mapper_primitive(newC_int64_t)(p.lock_timeout),
+	}
+}
+func ownRollbackRequest(p C.RollbackRequestRef) RollbackRequest {
+	return RollbackRequest{
+		ns:              ownString(p.ns),
+		release_name:    ownString(p.release_name),
+		revision:        newC_int64_t(p.revision),
+		wait:            newC_bool(p.wait),
+		wait_for_jobs:   newC_bool(p.wait_for_jobs),
+		timeout:         new_list_mapper(newC_int64_t)(p.timeout),
+		force:           newC_bool(p.force),
+		recreate:        newC_bool(p.recreate),
+		cleanup_on_fail: newC_bool(p.cleanup_on_fail),
+		disable_hooks:   newC_bool(p.disable_hooks),
+		dry_run:         newC_bool(p.dry_run),
+		env:             ownHelmEnv(p.env),
+		id:              newC_uint64_t(p.id),
+		lock_timeout:    new_list_mapper(newC_int64_t)(p.lock_timeout),
+	}
+}
+func cntRollbackRequest(s *RollbackRequest, cnt *uint) [0]C.RollbackRequestRef {
+	cntHelmEnv(&s.env, cnt)
+	return [0]C.RollbackRequestRef{}
+}
+func refRollbackRequest(p *RollbackRequest, buffer *[]byte) C.RollbackRequestRef {
+	return C.RollbackRequestRef{
+		ns:              refString(&p.ns, buffer),
+		release_name:    refString(&p.release_name, buffer),
+		revision:        refC_int64_t(&p.revision, buffer),
+		wait:            refC_bool(&p.wait, buffer),
+		wait_for_jobs:   refC_bool(&p.wait_for_jobs, buffer),
+		timeout:         ref_list_mapper_primitive(refC_int64_t)(&p.timeout, buffer),
+		force:           refC_bool(&p.force, buffer),
+		recreate:        refC_bool(&p.recreate, buffer),
+		cleanup_on_fail: refC_bool(&p.cleanup_on_fail, buffer),
+		disable_hooks:   refC_bool(&p.disable_hooks, buffer),
+		dry_run:         refC_bool(&p.dry_run, buffer),
+		env:             refHelmEnv(&p.env, buffer),
+		id:              refC_uint64_t(&p.id, buffer),
+		lock_timeout:    ref_list_mapper_primitive(refC_int64_t)(&p.lock_timeout, buffer),
+	}
+}
+
+type RollbackResponse struct {
+	err       []string
+	err_kind  string
+	logs      []string
+	data      string
+	cancelled bool
+	release   []ReleaseSummary
+	lock_wait int64
+}
+
+func newRollbackResponse(p C.RollbackResponseRef) RollbackResponse {
+	return RollbackResponse{
+		err:       new_list_mapper(newString)(p.err),
+		err_kind:  newString(p.err_kind),
+		logs:      new_list_mapper(newString)(p.logs),
+		data:      newString(p.data),
+		cancelled: newC_bool(p.cancelled),
+		release:   new_list_mapper(newReleaseSummary)(p.release),
+		lock_wait: newC_int64_t(p.lock_wait),
+	}
+}
+func ownRollbackResponse(p C.RollbackResponseRef) RollbackResponse {
+	return RollbackResponse{
+		err:       new_list_mapper(ownString)(p.err),
+		err_kind:  ownString(p.err_kind),
+		logs:      new_list_mapper(ownString)(p.logs),
+		data:      ownString(p.data),
+		cancelled: newC_bool(p.cancelled),
+		release:   new_list_mapper(ownReleaseSummary)(p.release),
+		lock_wait: newC_int64_t(p.lock_wait),
+	}
+}
+func cntRollbackResponse(s *RollbackResponse, cnt *uint) [0]C.RollbackResponseRef {
+	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
+	cnt_list_mapper(cntReleaseSummary)(&s.release, cnt)
+	return [0]C.RollbackResponseRef{}
+}
+func refRollbackResponse(p *RollbackResponse, buffer *[]byte) C.RollbackResponseRef {
+	return C.RollbackResponseRef{
+		err:       ref_list_mapper(refString)(&p.err, buffer),
+		err_kind:  refString(&p.err_kind, buffer),
+		logs:      ref_list_mapper(refString)(&p.logs, buffer),
+		data:      refString(&p.data, buffer),
+		cancelled: refC_bool(&p.cancelled, buffer),
+		release:   ref_list_mapper(refReleaseSummary)(&p.release, buffer),
+		lock_wait: refC_int64_t(&p.lock_wait, buffer),
 	}
 }
 
@@ -1092,26 +2968,574 @@ func refLoginRequest(p *LoginRequest, buffer *[]byte) C.LoginRequestRef {
 }
 
 type LoginResponse struct {
-	err []string
+	err      []string
+	err_kind string
+	logs     []string
 }
 
 func newLoginResponse(p C.LoginResponseRef) LoginResponse {
 	return LoginResponse{
-		err: new_list_mapper(newString)(p.err),
+		err:      new_list_mapper(newString)(p.err),
+		err_kind: newString(p.err_kind),
+		logs:     new_list_mapper(newString)(p.logs),
 	}
 }
 func ownLoginResponse(p C.LoginResponseRef) LoginResponse {
 	return LoginResponse{
-		err: new_list_mapper(ownString)(p.err),
+		err:      new_list_mapper(ownString)(p.err),
+		err_kind: ownString(p.err_kind),
+		logs:     new_list_mapper(ownString)(p.logs),
 	}
 }
 func cntLoginResponse(s *LoginResponse, cnt *uint) [0]C.LoginResponseRef {
 	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
 	return [0]C.LoginResponseRef{}
 }
 func refLoginResponse(p *LoginResponse, buffer *[]byte) C.LoginResponseRef {
 	return C.LoginResponseRef{
-		err: ref_list_mapper(refString)(&p.err, buffer),
+		err:      ref_list_mapper(refString)(&p.err, buffer),
+		err_kind: refString(&p.err_kind, buffer),
+		logs:     ref_list_mapper(refString)(&p.logs, buffer),
+	}
+}
+
+type PullRequest struct {
+	chart                string
+	version              string
+	dest_dir             string
+	untar                bool
+	untar_dir            string
+	prov                 bool
+	devel                bool
+	cert_file            string
+	key_file             string
+	ca_file              string
+	insecure             bool
+	plain_http           bool
+	username             string
+	password             string
+	pass_credentials_all bool
+	repo_url             string
+	keyring              string
+	verify               bool
+	env                  HelmEnv
+}
+
+func newPullRequest(p C.PullRequestRef) PullRequest {
+	return PullRequest{
+		chart:                newString(p.chart),
+		version:              newString(p.version),
+		dest_dir:             newString(p.dest_dir),
+		untar:                newC_bool(p.untar),
+		untar_dir:            newString(p.untar_dir),
+		prov:                 newC_bool(p.prov),
+		devel:                newC_bool(p.devel),
+		cert_file:            newString(p.cert_file),
+		key_file:             newString(p.key_file),
+		ca_file:              newString(p.ca_file),
+		insecure:             newC_bool(p.insecure),
+		plain_http:           newC_bool(p.plain_http),
+		username:             newString(p.username),
+		password:             newString(p.password),
+		pass_credentials_all: newC_bool(p.pass_credentials_all),
+		repo_url:             newString(p.repo_url),
+		keyring:              newString(p.keyring),
+		verify:               newC_bool(p.verify),
+		env:                  newHelmEnv(p.env),
+	}
+}
+func ownPullRequest(p C.PullRequestRef) PullRequest {
+	return PullRequest{
+		chart:                ownString(p.chart),
+		version:              ownString(p.version),
+		dest_dir:             ownString(p.dest_dir),
+		untar:                newC_bool(p.untar),
+		untar_dir:            ownString(p.untar_dir),
+		prov:                 newC_bool(p.prov),
+		devel:                newC_bool(p.devel),
+		cert_file:            ownString(p.cert_file),
+		key_file:             ownString(p.key_file),
+		ca_file:              ownString(p.ca_file),
+		insecure:             newC_bool(p.insecure),
+		plain_http:           newC_bool(p.plain_http),
+		username:             ownString(p.username),
+		password:             ownString(p.password),
+		pass_credentials_all: newC_bool(p.pass_credentials_all),
+		repo_url:             ownString(p.repo_url),
+		keyring:              ownString(p.keyring),
+		verify:               newC_bool(p.verify),
+		env:                  ownHelmEnv(p.env),
+	}
+}
+func cntPullRequest(s *PullRequest, cnt *uint) [0]C.PullRequestRef {
+	cntHelmEnv(&s.env, cnt)
+	return [0]C.PullRequestRef{}
+}
+func refPullRequest(p *PullRequest, buffer *[]byte) C.PullRequestRef {
+	return C.PullRequestRef{
+		chart:                refString(&p.chart, buffer),
+		version:              refString(&p.version, buffer),
+		dest_dir:             refString(&p.dest_dir, buffer),
+		untar:                refC_bool(&p.untar, buffer),
+		untar_dir:            refString(&p.untar_dir, buffer),
+		prov:                 refC_bool(&p.prov, buffer),
+		devel:                refC_bool(&p.devel, buffer),
+		cert_file:            refString(&p.cert_file, buffer),
+		key_file:             refString(&p.key_file, buffer),
+		ca_file:              refString(&p.ca_file, buffer),
+		insecure:             refC_bool(&p.insecure, buffer),
+		plain_http:           refC_bool(&p.plain_http, buffer),
+		username:             refString(&p.username, buffer),
+		password:             refString(&p.password, buffer),
+		pass_credentials_all: refC_bool(&p.pass_credentials_all, buffer),
+		repo_url:             refString(&p.repo_url, buffer),
+		keyring:              refString(&p.keyring, buffer),
+		verify:               refC_bool(&p.verify, buffer),
+		env:                  refHelmEnv(&p.env, buffer),
+	}
+}
+
+type PullResponse struct {
+	err      []string
+	err_kind string
+	logs     []string
+	path     string
+	digest   string
+}
+
+func newPullResponse(p C.PullResponseRef) PullResponse {
+	return PullResponse{
+		err:      new_list_mapper(newString)(p.err),
+		err_kind: newString(p.err_kind),
+		logs:     new_list_mapper(newString)(p.logs),
+		path:     newString(p.path),
+		digest:   newString(p.digest),
+	}
+}
+func ownPullResponse(p C.PullResponseRef) PullResponse {
+	return PullResponse{
+		err:      new_list_mapper(ownString)(p.err),
+		err_kind: ownString(p.err_kind),
+		logs:     new_list_mapper(ownString)(p.logs),
+		path:     ownString(p.path),
+		digest:   ownString(p.digest),
+	}
+}
+func cntPullResponse(s *PullResponse, cnt *uint) [0]C.PullResponseRef {
+	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
+	return [0]C.PullResponseRef{}
+}
+func refPullResponse(p *PullResponse, buffer *[]byte) C.PullResponseRef {
+	return C.PullResponseRef{
+		err:      ref_list_mapper(refString)(&p.err, buffer),
+		err_kind: refString(&p.err_kind, buffer),
+		logs:     ref_list_mapper(refString)(&p.logs, buffer),
+		path:     refString(&p.path, buffer),
+		digest:   refString(&p.digest, buffer),
+	}
+}
+
+type PushRequest struct {
+	chart      string
+	remote     string
+	cert_file  string
+	key_file   string
+	ca_file    string
+	insecure   bool
+	plain_http bool
+	env        HelmEnv
+}
+
+func newPushRequest(p C.PushRequestRef) PushRequest {
+	return PushRequest{
+		chart:      newString(p.chart),
+		remote:     newString(p.remote),
+		cert_file:  newString(p.cert_file),
+		key_file:   newString(p.key_file),
+		ca_file:    newString(p.ca_file),
+		insecure:   newC_bool(p.insecure),
+		plain_http: newC_bool(p.plain_http),
+		env:        newHelmEnv(p.env),
+	}
+}
+func ownPushRequest(p C.PushRequestRef) PushRequest {
+	return PushRequest{
+		chart:      ownString(p.chart),
+		remote:     ownString(p.remote),
+		cert_file:  ownString(p.cert_file),
+		key_file:   ownString(p.key_file),
+		ca_file:    ownString(p.ca_file),
+		insecure:   newC_bool(p.insecure),
+		plain_http: newC_bool(p.plain_http),
+		env:        ownHelmEnv(p.env),
+	}
+}
+func cntPushRequest(s *PushRequest, cnt *uint) [0]C.PushRequestRef {
+	cntHelmEnv(&s.env, cnt)
+	return [0]C.PushRequestRef{}
+}
+func refPushRequest(p *PushRequest, buffer *[]byte) C.PushRequestRef {
+	return C.PushRequestRef{
+		chart:      refString(&p.chart, buffer),
+		remote:     refString(&p.remote, buffer),
+		cert_file:  refString(&p.cert_file, buffer),
+		key_file:   refString(&p.key_file, buffer),
+		ca_file:    refString(&p.ca_file, buffer),
+		insecure:   refC_bool(&p.insecure, buffer),
+		plain_http: refC_bool(&p.plain_http, buffer),
+		env:        refHelmEnv(&p.env, buffer),
+	}
+}
+
+type PushResponse struct {
+	err        []string
+	err_kind   string
+	logs       []string
+	pushed_ref string
+	digest     string
+}
+
+func newPushResponse(p C.PushResponseRef) PushResponse {
+	return PushResponse{
+		err:        new_list_mapper(newString)(p.err),
+		err_kind:   newString(p.err_kind),
+		logs:       new_list_mapper(newString)(p.logs),
+		pushed_ref: newString(p.pushed_ref),
+		digest:     newString(p.digest),
+	}
+}
+func ownPushResponse(p C.PushResponseRef) PushResponse {
+	return PushResponse{
+		err:        new_list_mapper(ownString)(p.err),
+		err_kind:   ownString(p.err_kind),
+		logs:       new_list_mapper(ownString)(p.logs),
+		pushed_ref: ownString(p.pushed_ref),
+		digest:     ownString(p.digest),
+	}
+}
+func cntPushResponse(s *PushResponse, cnt *uint) [0]C.PushResponseRef {
+	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
+	return [0]C.PushResponseRef{}
+}
+func refPushResponse(p *PushResponse, buffer *[]byte) C.PushResponseRef {
+	return C.PushResponseRef{
+		err:        ref_list_mapper(refString)(&p.err, buffer),
+		err_kind:   refString(&p.err_kind, buffer),
+		logs:       ref_list_mapper(refString)(&p.logs, buffer),
+		pushed_ref: refString(&p.pushed_ref, buffer),
+		digest:     refString(&p.digest, buffer),
+	}
+}
+
+type PackageRequest struct {
+	path              string
+	destination       string
+	version           string
+	app_version       string
+	dependency_update bool
+	sign              bool
+	key               string
+	keyring           string
+	passphrase_file   string
+	env               HelmEnv
+}
+
+func newPackageRequest(p C.PackageRequestRef) PackageRequest {
+	return PackageRequest{
+		path:              newString(p.path),
+		destination:       newString(p.destination),
+		version:           newString(p.version),
+		app_version:       newString(p.app_version),
+		dependency_update: newC_bool(p.dependency_update),
+		sign:              newC_bool(p.sign),
+		key:               newString(p.key),
+		keyring:           newString(p.keyring),
+		passphrase_file:   newString(p.passphrase_file),
+		env:               newHelmEnv(p.env),
+	}
+}
+func ownPackageRequest(p C.PackageRequestRef) PackageRequest {
+	return PackageRequest{
+		path:              ownString(p.path),
+		destination:       ownString(p.destination),
+		version:           ownString(p.version),
+		app_version:       ownString(p.app_version),
+		dependency_update: newC_bool(p.dependency_update),
+		sign:              newC_bool(p.sign),
+		key:               ownString(p.key),
+		keyring:           ownString(p.keyring),
+		passphrase_file:   ownString(p.passphrase_file),
+		env:               ownHelmEnv(p.env),
+	}
+}
+func cntPackageRequest(s *PackageRequest, cnt *uint) [0]C.PackageRequestRef {
+	cntHelmEnv(&s.env, cnt)
+	return [0]C.PackageRequestRef{}
+}
+func refPackageRequest(p *PackageRequest, buffer *[]byte) C.PackageRequestRef {
+	return C.PackageRequestRef{
+		path:              refString(&p.path, buffer),
+		destination:       refString(&p.destination, buffer),
+		version:           refString(&p.version, buffer),
+		app_version:       refString(&p.app_version, buffer),
+		dependency_update: refC_bool(&p.dependency_update, buffer),
+		sign:              refC_bool(&p.sign, buffer),
+		key:               refString(&p.key, buffer),
+		keyring:           refString(&p.keyring, buffer),
+		passphrase_file:   refString(&p.passphrase_file, buffer),
+		env:               refHelmEnv(&p.env, buffer),
+	}
+}
+
+type PackageResponse struct {
+	err      []string
+	err_kind string
+	logs     []string
+	path     string
+}
+
+func newPackageResponse(p C.PackageResponseRef) PackageResponse {
+	return PackageResponse{
+		err:      new_list_mapper(newString)(p.err),
+		err_kind: newString(p.err_kind),
+		logs:     new_list_mapper(newString)(p.logs),
+		path:     newString(p.path),
+	}
+}
+func ownPackageResponse(p C.PackageResponseRef) PackageResponse {
+	return PackageResponse{
+		err:      new_list_mapper(ownString)(p.err),
+		err_kind: ownString(p.err_kind),
+		logs:     new_list_mapper(ownString)(p.logs),
+		path:     ownString(p.path),
+	}
+}
+func cntPackageResponse(s *PackageResponse, cnt *uint) [0]C.PackageResponseRef {
+	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
+	return [0]C.PackageResponseRef{}
+}
+func refPackageResponse(p *PackageResponse, buffer *[]byte) C.PackageResponseRef {
+	return C.PackageResponseRef{
+		err:      ref_list_mapper(refString)(&p.err, buffer),
+		err_kind: refString(&p.err_kind, buffer),
+		logs:     ref_list_mapper(refString)(&p.logs, buffer),
+		path:     refString(&p.path, buffer),
+	}
+}
+
+type ProgressEvent struct {
+	kind     string
+	phase    string
+	message  string
+	resource string
+	time     int64
+}
+
+func newProgressEvent(p C.ProgressEventRef) ProgressEvent {
+	return ProgressEvent{
+		kind:     newString(p.kind),
+		phase:    newString(p.phase),
+		message:  newString(p.message),
+		resource: newString(p.resource),
+		time:     newC_int64_t(p.time),
+	}
+}
+func ownProgressEvent(p C.ProgressEventRef) ProgressEvent {
+	return ProgressEvent{
+		kind:     ownString(p.kind),
+		phase:    ownString(p.phase),
+		message:  ownString(p.message),
+		resource: ownString(p.resource),
+		time:     newC_int64_t(p.time),
+	}
+}
+func cntProgressEvent(s *ProgressEvent, cnt *uint) [0]C.ProgressEventRef {
+	_ = s
+	_ = cnt
+	return [0]C.ProgressEventRef{}
+}
+func refProgressEvent(p *ProgressEvent, buffer *[]byte) C.ProgressEventRef {
+	return C.ProgressEventRef{
+		kind:     refString(&p.kind, buffer),
+		phase:    refString(&p.phase, buffer),
+		message:  refString(&p.message, buffer),
+		resource: refString(&p.resource, buffer),
+		time:     refC_int64_t(&p.time, buffer),
+	}
+}
+
+type EventsRequest struct {
+	id uint64
+}
+
+func newEventsRequest(p C.EventsRequestRef) EventsRequest {
+	return EventsRequest{
+		id: newC_uint64_t(p.id),
+	}
+}
+func ownEventsRequest(p C.EventsRequestRef) EventsRequest {
+	return EventsRequest{
+		id: newC_uint64_t(p.id),
+	}
+}
+func cntEventsRequest(s *EventsRequest, cnt *uint) [0]C.EventsRequestRef {
+	_ = s
+	_ = cnt
+	return [0]C.EventsRequestRef{}
+}
+func refEventsRequest(p *EventsRequest, buffer *[]byte) C.EventsRequestRef {
+	return C.EventsRequestRef{
+		id: refC_uint64_t(&p.id, buffer),
+	}
+}
+
+type EventsResponse struct {
+	events []ProgressEvent
+	done   bool
+}
+
+func newEventsResponse(p C.EventsResponseRef) EventsResponse {
+	return EventsResponse{
+		events: new_list_mapper(newProgressEvent)(p.events),
+		done:   newC_bool(p.done),
+	}
+}
+func ownEventsResponse(p C.EventsResponseRef) EventsResponse {
+	return EventsResponse{
+		events: new_list_mapper(ownProgressEvent)(p.events),
+		done:   newC_bool(p.done),
+	}
+}
+func cntEventsResponse(s *EventsResponse, cnt *uint) [0]C.EventsResponseRef {
+	cnt_list_mapper(cntProgressEvent)(&s.events, cnt)
+	return [0]C.EventsResponseRef{}
+}
+func refEventsResponse(p *EventsResponse, buffer *[]byte) C.EventsResponseRef {
+	return C.EventsResponseRef{
+		events: ref_list_mapper(refProgressEvent)(&p.events, buffer),
+		done:   refC_bool(&p.done, buffer),
+	}
+}
+
+type WarmClientsRequest struct {
+	ns  string
+	env HelmEnv
+}
+
+func newWarmClientsRequest(p C.WarmClientsRequestRef) WarmClientsRequest {
+	return WarmClientsRequest{
+		ns:  newString(p.ns),
+		env: newHelmEnv(p.env),
+	}
+}
+func ownWarmClientsRequest(p C.WarmClientsRequestRef) WarmClientsRequest {
+	return WarmClientsRequest{
+		ns:  ownString(p.ns),
+		env: ownHelmEnv(p.env),
+	}
+}
+func cntWarmClientsRequest(s *WarmClientsRequest, cnt *uint) [0]C.WarmClientsRequestRef {
+	cntHelmEnv(&s.env, cnt)
+	return [0]C.WarmClientsRequestRef{}
+}
+func refWarmClientsRequest(p *WarmClientsRequest, buffer *[]byte) C.WarmClientsRequestRef {
+	return C.WarmClientsRequestRef{
+		ns:  refString(&p.ns, buffer),
+		env: refHelmEnv(&p.env, buffer),
+	}
+}
+
+type WarmClientsResponse struct {
+	err      []string
+	err_kind string
+	logs     []string
+}
+
+func newWarmClientsResponse(p C.WarmClientsResponseRef) WarmClientsResponse {
+	return WarmClientsResponse{
+		err:      new_list_mapper(newString)(p.err),
+		err_kind: newString(p.err_kind),
+		logs:     new_list_mapper(newString)(p.logs),
+	}
+}
+func ownWarmClientsResponse(p C.WarmClientsResponseRef) WarmClientsResponse {
+	return WarmClientsResponse{
+		err:      new_list_mapper(ownString)(p.err),
+		err_kind: ownString(p.err_kind),
+		logs:     new_list_mapper(ownString)(p.logs),
+	}
+}
+func cntWarmClientsResponse(s *WarmClientsResponse, cnt *uint) [0]C.WarmClientsResponseRef {
+	cnt_list_mapper(cntString)(&s.err, cnt)
+	cnt_list_mapper(cntString)(&s.logs, cnt)
+	return [0]C.WarmClientsResponseRef{}
+}
+func refWarmClientsResponse(p *WarmClientsResponse, buffer *[]byte) C.WarmClientsResponseRef {
+	return C.WarmClientsResponseRef{
+		err:      ref_list_mapper(refString)(&p.err, buffer),
+		err_kind: refString(&p.err_kind, buffer),
+		logs:     ref_list_mapper(refString)(&p.logs, buffer),
+	}
+}
+
+type InvalidateClientsRequest struct {
+	ns  string
+	env HelmEnv
+	all bool
+}
+
+func newInvalidateClientsRequest(p C.InvalidateClientsRequestRef) InvalidateClientsRequest {
+	return InvalidateClientsRequest{
+		ns:  newString(p.ns),
+		env: newHelmEnv(p.env),
+		all: newC_bool(p.all),
+	}
+}
+func ownInvalidateClientsRequest(p C.InvalidateClientsRequestRef) InvalidateClientsRequest {
+	return InvalidateClientsRequest{
+		ns:  ownString(p.ns),
+		env: ownHelmEnv(p.env),
+		all: newC_bool(p.all),
+	}
+}
+func cntInvalidateClientsRequest(s *InvalidateClientsRequest, cnt *uint) [0]C.InvalidateClientsRequestRef {
+	cntHelmEnv(&s.env, cnt)
+	return [0]C.InvalidateClientsRequestRef{}
+}
+func refInvalidateClientsRequest(p *InvalidateClientsRequest, buffer *[]byte) C.InvalidateClientsRequestRef {
+	return C.InvalidateClientsRequestRef{
+		ns:  refString(&p.ns, buffer),
+		env: refHelmEnv(&p.env, buffer),
+		all: refC_bool(&p.all, buffer),
+	}
+}
+
+type CancelRequest struct {
+	id uint64
+}
+
+func newCancelRequest(p C.CancelRequestRef) CancelRequest {
+	return CancelRequest{
+		id: newC_uint64_t(p.id),
+	}
+}
+func ownCancelRequest(p C.CancelRequestRef) CancelRequest {
+	return CancelRequest{
+		id: newC_uint64_t(p.id),
+	}
+}
+func cntCancelRequest(s *CancelRequest, cnt *uint) [0]C.CancelRequestRef {
+	_ = s
+	_ = cnt
+	return [0]C.CancelRequestRef{}
+}
+func refCancelRequest(p *CancelRequest, buffer *[]byte) C.CancelRequestRef {
+	return C.CancelRequestRef{
+		id: refC_uint64_t(&p.id, buffer),
 	}
 }
 func main() {}
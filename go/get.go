@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"helm.sh/helm/v3/pkg/action"
+)
+
+const (
+	getValues    = "values"
+	getAllValues = "all_values"
+	getManifest  = "manifest"
+	getNotes     = "notes"
+	getHooks     = "hooks"
+	getMetadata  = "metadata"
+)
+
+type getRelease struct {
+	ReleaseName string
+	What        string
+	Revision    int
+}
+
+// runGet returns values and metadata as JSON, and the manifest, notes and
+// hooks as text.
+func runGet(logger *log.Logger, settings *helmSettings, get getRelease) ([]byte, string, error) {
+	actionConfig, err := initActionConfig(settings, logger)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to init action config: %w", err)
+	}
+
+	switch get.What {
+	case getValues, getAllValues:
+		getValuesClient := action.NewGetValues(actionConfig)
+		getValuesClient.Version = get.Revision
+		getValuesClient.AllValues = get.What == getAllValues
+
+		values, err := getValuesClient.Run(get.ReleaseName)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to run get values action: %w", err)
+		}
+
+		data, err := json.Marshal(values)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to marshal values: %w", err)
+		}
+
+		return data, "", nil
+	case getMetadata:
+		getMetadataClient := action.NewGetMetadata(actionConfig)
+		getMetadataClient.Version = get.Revision
+
+		metadata, err := getMetadataClient.Run(get.ReleaseName)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to run get metadata action: %w", err)
+		}
+
+		data, err := json.Marshal(metadata)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+
+		return data, "", nil
+	case getManifest, getNotes, getHooks:
+		getClient := action.NewGet(actionConfig)
+		getClient.Version = get.Revision
+
+		release, err := getClient.Run(get.ReleaseName)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to run get action: %w", err)
+		}
+
+		switch get.What {
+		case getManifest:
+			return nil, release.Manifest, nil
+		case getNotes:
+			return nil, release.Info.Notes, nil
+		default:
+			return nil, hookManifests(release), nil
+		}
+	default:
+		return nil, "", fmt.Errorf("unknown get selector %q", get.What)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+)
+
+type history struct {
+	ReleaseName string
+	Max         int
+}
+
+func runHistory(logger *log.Logger, settings *helmSettings, history history) ([]*release.Release, error) {
+	actionConfig, err := initActionConfig(settings, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init action config: %w", err)
+	}
+
+	historyClient := action.NewHistory(actionConfig)
+	historyClient.Max = history.Max
+
+	releases, err := historyClient.Run(history.ReleaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run history action: %w", err)
+	}
+
+	// The history action does not apply Max itself, keep the latest revisions
+	// like `helm history --max` does
+	releaseutil.SortByRevision(releases)
+	if historyClient.Max > 0 && len(releases) > historyClient.Max {
+		releases = releases[len(releases)-historyClient.Max:]
+	}
+
+	return releases, nil
+}
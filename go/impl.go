@@ -1,17 +1,29 @@
 package main
 
 import (
+	"bufio"
 	"cmp"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/cli/values"
 	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
 )
 
 type Helm struct{}
@@ -22,6 +34,9 @@ func init() {
 
 // registry_login implements HelmCall.
 func (d Helm) registry_login(req *LoginRequest) (resp LoginResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
 	login := login{
 		hostname:  req.hostname,
 		username:  req.username,
@@ -33,8 +48,9 @@ func (d Helm) registry_login(req *LoginRequest) (resp LoginResponse) {
 		plainHTTP: req.plain_http,
 	}
 
-	if err := registryLogin(log.Default(), initSettings(req.env, ""), login); err != nil {
+	if err := registryLogin(logs.logger(), initSettings(req.env, ""), login); err != nil {
 		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
 
 		return
 	}
@@ -42,8 +58,118 @@ func (d Helm) registry_login(req *LoginRequest) (resp LoginResponse) {
 	return
 }
 
+// pull implements HelmCall.
+func (d Helm) pull(req *PullRequest) (resp PullResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
+	pull := pull{
+		ChartRef: req.chart,
+		Version:  req.version,
+		DestDir:  req.dest_dir,
+		Untar:    req.untar,
+		UntarDir: req.untar_dir,
+		Prov:     req.prov,
+		Devel:    req.devel,
+
+		ChartSource: chartSource{
+			CertFile:           req.cert_file,
+			KeyFile:            req.key_file,
+			CaFile:             req.ca_file,
+			Insecure:           req.insecure,
+			PlainHTTP:          req.plain_http,
+			Username:           req.username,
+			Password:           req.password,
+			PassCredentialsAll: req.pass_credentials_all,
+			RepoURL:            req.repo_url,
+			Keyring:            req.keyring,
+			Verify:             req.verify,
+		},
+	}
+
+	pulled, err := runPull(logs.logger(), initSettings(req.env, ""), pull)
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+
+		return
+	}
+
+	resp.path = pulled.Path
+	resp.digest = pulled.Digest
+
+	return
+}
+
+// push implements HelmCall.
+func (d Helm) push(req *PushRequest) (resp PushResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
+	push := push{
+		ChartPath: req.chart,
+		Remote:    req.remote,
+		CertFile:  req.cert_file,
+		KeyFile:   req.key_file,
+		CaFile:    req.ca_file,
+		Insecure:  req.insecure,
+		PlainHTTP: req.plain_http,
+	}
+
+	pushed, err := runPush(logs.logger(), initSettings(req.env, ""), push)
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+
+		return
+	}
+
+	resp.pushed_ref = pushed.Ref
+	resp.digest = pushed.Digest
+
+	return
+}
+
+// package_chart implements HelmCall.
+func (d Helm) package_chart(req *PackageRequest) (resp PackageResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
+	packageChart := packageChart{
+		Path:             req.path,
+		Destination:      req.destination,
+		Version:          req.version,
+		AppVersion:       req.app_version,
+		DependencyUpdate: req.dependency_update,
+
+		Sign:           req.sign,
+		Key:            req.key,
+		Keyring:        req.keyring,
+		PassphraseFile: req.passphrase_file,
+	}
+
+	path, err := runPackage(logs.logger(), initSettings(req.env, ""), packageChart)
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+
+		return
+	}
+
+	resp.path = path
+
+	return
+}
+
 // install implements DemoCall.
 func (d Helm) install(req *InstallRequest) (resp InstallResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
+	// The stream is closed on every return, the rust side polls it until then
+	events := openEvents(req.id, req.events)
+	defer events.close()
+
 	install := install{
 		ReleaseName:     req.release_name,
 		ChartRef:        req.chart,
@@ -51,21 +177,68 @@ func (d Helm) install(req *InstallRequest) (resp InstallResponse) {
 		Wait:            req.wait,
 		CreateNamespace: req.create_namespace,
 		DryRunOption:    req.dry_run,
+		Atomic:          req.atomic,
+		WaitForJobs:     req.wait_for_jobs,
+
+		DependencyUpdate: req.dependency_update,
+		ChartSource: chartSource{
+			CertFile:           req.cert_file,
+			KeyFile:            req.key_file,
+			CaFile:             req.ca_file,
+			Insecure:           req.insecure,
+			PlainHTTP:          req.plain_http,
+			Username:           req.username,
+			Password:           req.password,
+			PassCredentialsAll: req.pass_credentials_all,
+			RepoURL:            req.repo_url,
+			Keyring:            req.keyring,
+			Verify:             req.verify,
+		},
 	}
 
 	install.Timeout = get(req.timeout)
 
-	if len(req.values) > 0 {
-		if err := json.Unmarshal(req.values, &install.Values); err != nil {
-			resp.err = append(resp.err, err.Error())
+	settings := initSettings(req.env, req.ns)
 
-			return
-		}
+	merged, err := mergeValues(settings, values.Options{
+		ValueFiles:    req.values_files,
+		Values:        req.set_values,
+		StringValues:  req.set_string_values,
+		JSONValues:    req.set_json_values,
+		FileValues:    req.set_file_values,
+		LiteralValues: req.set_literal_values,
+	}, req.values)
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+
+		return
+	}
+	install.Values = merged
+
+	ctx, cancel := operationContext(req.id)
+	defer cancel()
+
+	unlock, waited, err := lockRelease(ctx, releaseKeyOf(settings, install.ReleaseName), lockTimeout(req.lock_timeout))
+	resp.lock_wait = waited.Milliseconds()
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+		resp.cancelled = errors.Is(ctx.Err(), context.Canceled)
+
+		return
 	}
+	defer unlock()
+
+	ctx = withEvents(ctx, events)
 
-	release, err := runInstall(context.TODO(), log.Default(), initSettings(req.env, req.ns), install)
+	release, recovery, err := runInstall(ctx, events.logger(logs.logger()), settings, install)
+	resp.uninstalled = recovery.Uninstalled
+	resp.revision = int64(recovery.Revision)
 	if err != nil {
 		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+		resp.cancelled = errors.Is(ctx.Err(), context.Canceled)
 
 		return
 	}
@@ -78,12 +251,20 @@ func (d Helm) install(req *InstallRequest) (resp InstallResponse) {
 	}
 
 	resp.data = string(data)
+	resp.release = []ReleaseSummary{releaseSummary(release)}
 
 	return
 }
 
 // upgrade implements HelmCall.
 func (d Helm) upgrade(req *UpgradeRequest) (resp UpgradeResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
+	// The stream is closed on every return, the rust side polls it until then
+	events := openEvents(req.id, req.events)
+	defer events.close()
+
 	upgrade := upgrade{
 		ReleaseName:  req.release_name,
 		ChartRef:     req.chart,
@@ -92,21 +273,79 @@ func (d Helm) upgrade(req *UpgradeRequest) (resp UpgradeResponse) {
 		DryRunOption: req.dry_run,
 		ReuseValues:  req.reuse_values,
 		ResetValues:  req.reset_values,
+
+		Install:         req.install,
+		CreateNamespace: req.create_namespace,
+
+		Atomic:        req.atomic,
+		CleanupOnFail: req.cleanup_on_fail,
+		WaitForJobs:   req.wait_for_jobs,
+		MaxHistory:    int(req.max_history),
+
+		RecoverPending: req.recover_pending,
+		RecoverPolicy:  req.recover_policy,
+		RecoverAfter:   time.Duration(req.recover_pending_after) * time.Second,
+
+		DependencyUpdate: req.dependency_update,
+		ChartSource: chartSource{
+			CertFile:           req.cert_file,
+			KeyFile:            req.key_file,
+			CaFile:             req.ca_file,
+			Insecure:           req.insecure,
+			PlainHTTP:          req.plain_http,
+			Username:           req.username,
+			Password:           req.password,
+			PassCredentialsAll: req.pass_credentials_all,
+			RepoURL:            req.repo_url,
+			Keyring:            req.keyring,
+			Verify:             req.verify,
+		},
 	}
 
 	upgrade.Timeout = get(req.timeout)
 
-	if len(req.values) > 0 {
-		if err := json.Unmarshal(req.values, &upgrade.Values); err != nil {
-			resp.err = append(resp.err, err.Error())
+	settings := initSettings(req.env, req.ns)
 
-			return
-		}
+	merged, err := mergeValues(settings, values.Options{
+		ValueFiles:    req.values_files,
+		Values:        req.set_values,
+		StringValues:  req.set_string_values,
+		JSONValues:    req.set_json_values,
+		FileValues:    req.set_file_values,
+		LiteralValues: req.set_literal_values,
+	}, req.values)
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+
+		return
+	}
+	upgrade.Values = merged
+
+	ctx, cancel := operationContext(req.id)
+	defer cancel()
+
+	unlock, waited, err := lockRelease(ctx, releaseKeyOf(settings, upgrade.ReleaseName), lockTimeout(req.lock_timeout))
+	resp.lock_wait = waited.Milliseconds()
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+		resp.cancelled = errors.Is(ctx.Err(), context.Canceled)
+
+		return
 	}
+	defer unlock()
+
+	ctx = withEvents(ctx, events)
 
-	release, err := runUpgrade(context.TODO(), log.Default(), initSettings(req.env, req.ns), upgrade)
+	release, recovery, err := runUpgrade(ctx, events.logger(logs.logger()), settings, upgrade)
+	resp.rolled_back = recovery.RolledBack
+	resp.uninstalled = recovery.Uninstalled
+	resp.revision = int64(recovery.Revision)
 	if err != nil {
 		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+		resp.cancelled = errors.Is(ctx.Err(), context.Canceled)
 
 		return
 	}
@@ -119,27 +358,85 @@ func (d Helm) upgrade(req *UpgradeRequest) (resp UpgradeResponse) {
 	}
 
 	resp.data = string(data)
+	resp.release = []ReleaseSummary{releaseSummary(release)}
+
+	return
+}
+
+// template implements HelmCall.
+func (d Helm) template(req *TemplateRequest) (resp TemplateResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
+	template := template{
+		ReleaseName:  cmp.Or(req.release_name, "release-name"),
+		ChartRef:     req.chart,
+		ChartVersion: req.version,
+		KubeVersion:  get(req.kube_version),
+		APIVersions:  req.api_versions,
+		IncludeCRDs:  req.include_crds,
+		IsUpgrade:    req.is_upgrade,
+
+		DependencyUpdate: req.dependency_update,
+		ChartSource: chartSource{
+			CertFile:           req.cert_file,
+			KeyFile:            req.key_file,
+			CaFile:             req.ca_file,
+			Insecure:           req.insecure,
+			PlainHTTP:          req.plain_http,
+			Username:           req.username,
+			Password:           req.password,
+			PassCredentialsAll: req.pass_credentials_all,
+			RepoURL:            req.repo_url,
+			Keyring:            req.keyring,
+			Verify:             req.verify,
+		},
+	}
+
+	if len(req.values) > 0 {
+		if err := json.Unmarshal(req.values, &template.Values); err != nil {
+			resp.err = append(resp.err, err.Error())
+			resp.err_kind = errorKind(err)
+
+			return
+		}
+	}
+
+	release, err := runTemplate(context.TODO(), logs.logger(), initSettings(req.env, req.ns), template)
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+
+		return
+	}
+
+	resp.manifest = release.Manifest
+	resp.notes = release.Info.Notes
+	resp.hooks = hookManifests(release)
 
 	return
 }
 
 // list implements HelmCall.
 func (d Helm) list(req *ListRequest) (resp ListResponse) {
-	logger := log.Default()
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
+	logger := logs.logger()
 
 	actionConfig, err := initActionConfigList(initSettings(req.env, req.ns), logger, req.all_namespaces)
 	if err != nil {
 		resp.err = append(resp.err, fmt.Errorf("failed to init action config: %w", err).Error())
+		resp.err_kind = errorKind(err)
 
 		return
 	}
 
 	listClient := action.NewList(actionConfig)
 	listClient.AllNamespaces = req.all_namespaces
+	listClient.All = req.all
 
 	listClient.Sort = action.Sorter(req.sort)
-	listClient.StateMask = action.ListStates(req.state_mask)
-	listClient.SetStateMask()
 
 	listClient.ByDate = req.by_date
 	listClient.SortReverse = req.sort_reverse
@@ -156,9 +453,17 @@ func (d Helm) list(req *ListRequest) (resp ListResponse) {
 	listClient.Pending = req.pending
 	listClient.Selector = req.selector
 
+	// The state flags must be set before computing the mask, an explicit mask
+	// takes precedence over them unless all states are requested
+	listClient.SetStateMask()
+	if req.state_mask != 0 && !listClient.All {
+		listClient.StateMask = action.ListStates(req.state_mask)
+	}
+
 	releases, err := runList(listClient)
 	if err != nil {
 		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
 
 		return
 	}
@@ -173,12 +478,272 @@ func (d Helm) list(req *ListRequest) (resp ListResponse) {
 	if len(releases) > 0 {
 		resp.data = string(data)
 	}
+	resp.releases = releaseSummaries(releases)
+
+	return
+}
+
+// list_page implements HelmCall.
+func (d Helm) list_page(req *ListPageRequest) (resp ListPageResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
+	listPage := listPage{
+		AllNamespaces: req.all_namespaces,
+		All:           req.all,
+		StateMask:     action.ListStates(req.state_mask),
+		Deployed:      req.deployed,
+		Failed:        req.failed,
+		Pending:       req.pending,
+		Uninstalled:   req.uninstalled,
+		Uninstalling:  req.uninstalling,
+		Superseded:    req.superseded,
+		Filter:        req.filter,
+		Selector:      req.selector,
+		ByDate:        req.by_date,
+		SortReverse:   req.sort_reverse,
+		PageSize:      int(req.page_size),
+		Cursor:        req.cursor,
+	}
+
+	releases, next, err := runListPage(logs.logger(), initSettings(req.env, req.ns), listPage)
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+
+		return
+	}
+
+	resp.releases = releaseSummaries(releases)
+	resp.next_cursor = next
+
+	return
+}
+
+// status implements HelmCall.
+func (d Helm) status(req *StatusRequest) (resp StatusResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
+	status := status{
+		ReleaseName:   req.release_name,
+		Revision:      int(req.revision),
+		ShowResources: req.show_resources,
+	}
+
+	release, readiness, err := runStatus(context.TODO(), logs.logger(), initSettings(req.env, req.ns), status)
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+
+		if release == nil {
+			return
+		}
+	}
+
+	data, err := json.Marshal(release)
+	if err != nil {
+		resp.err = append(resp.err, fmt.Errorf("failed to marshal release from status: %w", err).Error())
+
+		return
+	}
+
+	resp.data = string(data)
+
+	for _, r := range readiness {
+		resp.resources = append(resp.resources, ResourceReadiness{
+			kind:      r.Kind,
+			name:      r.Name,
+			namespace: r.Namespace,
+			ready:     r.Ready,
+			reason:    r.Reason,
+		})
+	}
+
+	return
+}
+
+// get implements HelmCall.
+func (d Helm) get(req *GetRequest) (resp GetResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
+	get := getRelease{
+		ReleaseName: req.release_name,
+		What:        req.what,
+		Revision:    int(req.revision),
+	}
+
+	data, text, err := runGet(logs.logger(), initSettings(req.env, req.ns), get)
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+
+		return
+	}
+
+	resp.json = data
+	resp.text = text
+
+	return
+}
+
+// history implements HelmCall.
+func (d Helm) history(req *HistoryRequest) (resp HistoryResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
+	history := history{
+		ReleaseName: req.release_name,
+		Max:         int(req.max),
+	}
+
+	releases, err := runHistory(logs.logger(), initSettings(req.env, req.ns), history)
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+
+		return
+	}
+
+	data, err := json.Marshal(releases)
+	if err != nil {
+		resp.err = append(resp.err, fmt.Errorf("failed to marshal releases from history: %w", err).Error())
+
+		return
+	}
+
+	if len(releases) > 0 {
+		resp.data = string(data)
+	}
+
+	return
+}
+
+// rollback implements HelmCall.
+func (d Helm) rollback(req *RollbackRequest) (resp RollbackResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
+	rollback := rollback{
+		ReleaseName:   req.release_name,
+		Revision:      int(req.revision),
+		Wait:          req.wait,
+		WaitForJobs:   req.wait_for_jobs,
+		Force:         req.force,
+		Recreate:      req.recreate,
+		CleanupOnFail: req.cleanup_on_fail,
+		DisableHooks:  req.disable_hooks,
+		DryRun:        req.dry_run,
+	}
+
+	rollback.Timeout = get(req.timeout)
+
+	settings := initSettings(req.env, req.ns)
+
+	ctx, cancel := operationContext(req.id)
+	defer cancel()
+
+	unlock, waited, err := lockRelease(ctx, releaseKeyOf(settings, rollback.ReleaseName), lockTimeout(req.lock_timeout))
+	resp.lock_wait = waited.Milliseconds()
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+		resp.cancelled = errors.Is(ctx.Err(), context.Canceled)
+
+		return
+	}
+	defer unlock()
+
+	release, err := runRollback(ctx, logs.logger(), settings, rollback)
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+		resp.cancelled = errors.Is(ctx.Err(), context.Canceled)
+
+		return
+	}
+
+	data, err := json.Marshal(release)
+	if err != nil {
+		resp.err = append(resp.err, fmt.Errorf("failed to marshal release from rollback: %w", err).Error())
+
+		return
+	}
+
+	resp.data = string(data)
+	resp.release = []ReleaseSummary{releaseSummary(release)}
+
+	return
+}
+
+// recover_release implements HelmCall.
+func (d Helm) recover_release(req *RecoverRequest) (resp RecoverResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
+	recoverRelease := recoverRelease{
+		ReleaseName: req.release_name,
+		Policy:      req.policy,
+		MinAge:      time.Duration(req.min_age) * time.Second,
+		Wait:        req.wait,
+	}
+
+	recoverRelease.Timeout = get(req.timeout)
+
+	settings := initSettings(req.env, req.ns)
+
+	ctx, cancel := operationContext(req.id)
+	defer cancel()
+
+	unlock, waited, err := lockRelease(ctx, releaseKeyOf(settings, recoverRelease.ReleaseName), lockTimeout(req.lock_timeout))
+	resp.lock_wait = waited.Milliseconds()
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+		resp.cancelled = errors.Is(ctx.Err(), context.Canceled)
+
+		return
+	}
+	defer unlock()
+
+	release, recovered, err := runRecover(ctx, logs.logger(), settings, recoverRelease)
+	resp.status = recovered.Status.String()
+	resp.recovered = recovered.Recovered
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+		resp.cancelled = errors.Is(ctx.Err(), context.Canceled)
+
+		return
+	}
+
+	if release == nil {
+		return
+	}
+
+	data, err := json.Marshal(release)
+	if err != nil {
+		resp.err = append(resp.err, fmt.Errorf("failed to marshal release from recover: %w", err).Error())
+
+		return
+	}
+
+	resp.data = string(data)
+	resp.release = []ReleaseSummary{releaseSummary(release)}
 
 	return
 }
 
 // uninstall implements HelmCall.
 func (d Helm) uninstall(req *UninstallRequest) (resp UninstallResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
+	// The stream is closed on every return, the rust side polls it until then
+	events := openEvents(req.id, req.events)
+	defer events.close()
+
 	settings := initSettings(req.env, req.ns)
 
 	uninstall := uninstall{
@@ -194,9 +759,27 @@ func (d Helm) uninstall(req *UninstallRequest) (resp UninstallResponse) {
 
 	uninstall.Timeout = get(req.timeout)
 
-	release, err := runUninstall(log.Default(), settings, uninstall)
+	ctx, cancel := operationContext(req.id)
+	defer cancel()
+
+	unlock, waited, err := lockRelease(ctx, releaseKeyOf(settings, uninstall.ReleaseName), lockTimeout(req.lock_timeout))
+	resp.lock_wait = waited.Milliseconds()
+	if err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+		resp.cancelled = errors.Is(ctx.Err(), context.Canceled)
+
+		return
+	}
+	defer unlock()
+
+	ctx = withEvents(ctx, events)
+
+	release, err := runUninstall(ctx, events.logger(logs.logger()), settings, uninstall)
 	if err != nil {
 		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+		resp.cancelled = errors.Is(ctx.Err(), context.Canceled)
 
 		return
 	}
@@ -209,12 +792,18 @@ func (d Helm) uninstall(req *UninstallRequest) (resp UninstallResponse) {
 	}
 
 	resp.data = string(data)
+	if release != nil && release.Release != nil {
+		resp.release = []ReleaseSummary{releaseSummary(release.Release)}
+	}
 
 	return
 }
 
 // search implements HelmCall.
 func (d Helm) repo_search(req *SearchRequest) (resp SearchResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
 	settings := initSettings(req.env, "")
 
 	search := searchRepoOptions{
@@ -230,6 +819,7 @@ func (d Helm) repo_search(req *SearchRequest) (resp SearchResponse) {
 	searchResult, err := search.run()
 	if err != nil {
 		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
 
 		return
 	}
@@ -250,6 +840,9 @@ func (d Helm) repo_search(req *SearchRequest) (resp SearchResponse) {
 
 // repo_add implements HelmCall.
 func (d Helm) repo_add(req *AddRequest) (resp AddResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
 	settings := initSettings(req.env, "")
 
 	add := repoAddOptions{
@@ -268,9 +861,25 @@ func (d Helm) repo_add(req *AddRequest) (resp AddResponse) {
 		repoCache:             settings.RepositoryCache,
 	}
 
-	err := add.run(log.Default(), settings)
+	err := add.run(logs.logger(), settings)
 	if err != nil {
 		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
+
+		return
+	}
+
+	return
+}
+
+// warm_clients implements HelmCall.
+func (d Helm) warm_clients(req *WarmClientsRequest) (resp WarmClientsResponse) {
+	logs := newRequestLogger(req.env)
+	defer func() { resp.logs = logs.captured() }()
+
+	if err := warmClients(logs.logger(), initSettings(req.env, req.ns)); err != nil {
+		resp.err = append(resp.err, err.Error())
+		resp.err_kind = errorKind(err)
 
 		return
 	}
@@ -278,6 +887,38 @@ func (d Helm) repo_add(req *AddRequest) (resp AddResponse) {
 	return
 }
 
+// invalidate_clients implements HelmCall.
+func (d Helm) invalidate_clients(req *InvalidateClientsRequest) {
+	invalidateClients(initSettings(req.env, req.ns), req.all)
+}
+
+// next_events implements HelmCall.
+func (d Helm) next_events(req *EventsRequest) (resp EventsResponse) {
+	events, done := nextEvents(req.id)
+	for _, e := range events {
+		resp.events = append(resp.events, ProgressEvent{
+			kind:     e.Kind,
+			phase:    e.Phase,
+			message:  e.Message,
+			resource: e.Resource,
+			time:     e.Time.UnixMilli(),
+		})
+	}
+	resp.done = done
+
+	return
+}
+
+// open_events implements HelmCall.
+func (d Helm) open_events(req *EventsRequest) {
+	registerEvents(req.id)
+}
+
+// cancel implements HelmCall.
+func (d Helm) cancel(req *CancelRequest) {
+	cancelOperation(req.id)
+}
+
 func get[T any](from []T) T {
 	if len(from) > 0 {
 		return from[0]
@@ -297,23 +938,117 @@ func runList(listClient *action.List) ([]*release.Release, error) {
 
 var helmDriver string = os.Getenv("HELM_DRIVER")
 
-func initSettings(env HelmEnv, namespace string) *cli.EnvSettings {
+// memoryDrivers keep the releases of the requests using the memory driver,
+// so they persist across calls within the process. Helm moves a single driver
+// between namespaces with SetNamespace, which races between concurrent calls,
+// so each namespace gets its own driver.
+var memoryDrivers = struct {
+	sync.Mutex
+	byNamespace map[string]*driver.Memory
+}{byNamespace: map[string]*driver.Memory{}}
+
+// memoryDriver returns the memory driver of the namespace. An empty namespace
+// returns a snapshot of the releases of every namespace, for listing them.
+func memoryDriver(namespace string) (*driver.Memory, error) {
+	memoryDrivers.Lock()
+	defer memoryDrivers.Unlock()
+
+	if namespace != "" {
+		mem, ok := memoryDrivers.byNamespace[namespace]
+		if !ok {
+			mem = driver.NewMemory()
+			mem.SetNamespace(namespace)
+			memoryDrivers.byNamespace[namespace] = mem
+		}
+
+		return mem, nil
+	}
+
+	snapshot := driver.NewMemory()
+	for _, mem := range memoryDrivers.byNamespace {
+		releases, err := mem.List(func(*release.Release) bool { return true })
+		if err != nil {
+			return nil, err
+		}
+		for _, rls := range releases {
+			key := fmt.Sprintf("%s.%s.v%d", storage.HelmStorageType, rls.Name, rls.Version)
+			if err := snapshot.Create(key, rls); err != nil {
+				return nil, err
+			}
+		}
+	}
+	snapshot.SetNamespace("")
+
+	return snapshot, nil
+}
+
+// helmSettings extends the helm settings with the per request options helm
+// otherwise reads from the process environment.
+type helmSettings struct {
+	*cli.EnvSettings
+	// Driver is the release storage driver: secret, configmap, sql or memory
+	Driver string
+	// DriverConnection is the connection string of the sql driver
+	DriverConnection string
+	// FakeKubeClient replaces the cluster with a fake kube client, for tests
+	FakeKubeClient string
+	// Credentials are the inline cluster credentials
+	Credentials kubeCredentials
+	// ClientCacheTTL is how long the kube clients are reused, zero disables
+	// the cache, which is the default
+	ClientCacheTTL time.Duration
+
+	// namespace of the request, empty for the default one
+	namespace string
+}
+
+func initSettings(env HelmEnv, namespace string) *helmSettings {
 	settings := cli.New()
 	settings.KubeConfig = cmp.Or(get(env.kube_config), settings.KubeConfig)
 	settings.KubeContext = cmp.Or(get(env.kube_context), settings.KubeContext)
 	settings.KubeToken = cmp.Or(get(env.kube_token), settings.KubeToken)
 	settings.KubeCaFile = cmp.Or(get(env.kube_ca_file), settings.KubeCaFile)
 	settings.KubeInsecureSkipTLSVerify = settings.KubeInsecureSkipTLSVerify || env.kube_insecure_skip_tls_verify
+	settings.KubeAPIServer = cmp.Or(get(env.kube_api_server), settings.KubeAPIServer)
+	settings.KubeAsUser = cmp.Or(get(env.kube_as_user), settings.KubeAsUser)
+	if len(env.kube_as_groups) > 0 {
+		settings.KubeAsGroups = env.kube_as_groups
+	}
+	settings.KubeTLSServerName = cmp.Or(get(env.kube_tls_server_name), settings.KubeTLSServerName)
+	settings.BurstLimit = cmp.Or(int(get(env.burst_limit)), settings.BurstLimit)
+	settings.QPS = cmp.Or(get(env.qps), settings.QPS)
+	settings.Debug = settings.Debug || env.debug
 	settings.SetNamespace(namespace)
 
-	return settings
+	// The fake kube clients default to the memory driver, the secret and
+	// configmap drivers need a cluster
+	var fakeDriver string
+	if get(env.fake_kube_client) != "" {
+		fakeDriver = "memory"
+	}
+
+	return &helmSettings{
+		EnvSettings:      settings,
+		Driver:           cmp.Or(get(env.driver), fakeDriver, helmDriver),
+		DriverConnection: cmp.Or(get(env.driver_connection), os.Getenv("HELM_DRIVER_SQL_CONNECTION_STRING")),
+		FakeKubeClient:   get(env.fake_kube_client),
+		Credentials: kubeCredentials{
+			Config:    env.kube_config_data,
+			CAData:    env.kube_ca_data,
+			CertData:  env.kube_client_cert_data,
+			KeyData:   env.kube_client_key_data,
+			InCluster: env.kube_in_cluster,
+		},
+		ClientCacheTTL: time.Duration(get(env.client_cache_ttl)) * time.Second,
+		namespace:      namespace,
+	}
 }
 
-func initActionConfig(settings *cli.EnvSettings, logger *log.Logger) (*action.Configuration, error) {
+func initActionConfig(settings *helmSettings, logger *log.Logger) (*action.Configuration, error) {
 	return initActionConfigList(settings, logger, false)
 }
 
-func initActionConfigList(settings *cli.EnvSettings, logger *log.Logger, allNamespaces bool) (*action.Configuration, error) {
+func initActionConfigList(settings *helmSettings, logger *log.Logger, allNamespaces bool) (*action.Configuration, error) {
 
 	actionConfig := new(action.Configuration)
 
@@ -326,23 +1061,57 @@ func initActionConfigList(settings *cli.EnvSettings, logger *log.Logger, allName
 		return settings.Namespace()
 	}()
 
+	driverName := settings.Driver
+	switch driverName {
+	case "memory", "sql":
+		// Init sets the namespace of a memory driver and reads the sql
+		// connection string from the environment, the storage is replaced
+		// once the configuration is initialized
+		driverName = "secret"
+	}
+
 	if err := actionConfig.Init(
-		settings.RESTClientGetter(),
+		restClientGetter(settings),
 		namespace,
-		helmDriver,
-		logger.Printf); err != nil {
+		driverName,
+		debugLog(logger)); err != nil {
 		return nil, err
 	}
 
+	switch settings.Driver {
+	case "memory":
+		memDriver, err := memoryDriver(namespace)
+		if err != nil {
+			return nil, fmt.Errorf("unable to instantiate memory driver: %w", err)
+		}
+		actionConfig.Releases = storage.Init(memDriver)
+	case "sql":
+		sqlDriver, err := driver.NewSQL(settings.DriverConnection, debugLog(logger), namespace)
+		if err != nil {
+			return nil, fmt.Errorf("unable to instantiate SQL driver: %w", err)
+		}
+		actionConfig.Releases = storage.Init(sqlDriver)
+	}
+
+	if settings.FakeKubeClient != "" {
+		kubeClient, err := fakeKubeClient(settings.FakeKubeClient)
+		if err != nil {
+			return nil, err
+		}
+		actionConfig.KubeClient = kubeClient
+		actionConfig.Capabilities = chartutil.DefaultCapabilities.Copy()
+	}
+
 	return actionConfig, nil
 }
 
-func newRegistryClient(settings *cli.EnvSettings, plainHTTP bool) (*registry.Client, error) {
+func newRegistryClient(settings *helmSettings, logger *log.Logger, plainHTTP bool, username, password string) (*registry.Client, error) {
 	opts := []registry.ClientOption{
 		registry.ClientOptDebug(settings.Debug),
 		registry.ClientOptEnableCache(true),
-		registry.ClientOptWriter(os.Stderr),
+		registry.ClientOptWriter(logger.Writer()),
 		registry.ClientOptCredentialsFile(settings.RegistryConfig),
+		registry.ClientOptBasicAuth(username, password),
 	}
 	if plainHTTP {
 		opts = append(opts, registry.ClientOptPlainHTTP())
@@ -356,25 +1125,77 @@ func newRegistryClient(settings *cli.EnvSettings, plainHTTP bool) (*registry.Cli
 	return registryClient, nil
 }
 
-func newRegistryClientTLS(settings *cli.EnvSettings, logger *log.Logger, certFile, keyFile, caFile string, insecureSkipTLSverify, plainHTTP bool) (*registry.Client, error) {
+func newRegistryClientTLS(settings *helmSettings, logger *log.Logger, certFile, keyFile, caFile string, insecureSkipTLSverify, plainHTTP bool, username, password string) (*registry.Client, error) {
 	if certFile != "" && keyFile != "" || caFile != "" || insecureSkipTLSverify {
-		registryClient, err := registry.NewRegistryClientWithTLS(
-			logger.Writer(),
-			certFile,
-			keyFile,
-			caFile,
-			insecureSkipTLSverify,
-			settings.RegistryConfig,
-			settings.Debug)
+		tlsConf, err := newClientTLS(certFile, keyFile, caFile, insecureSkipTLSverify)
+		if err != nil {
+			return nil, fmt.Errorf("can't create TLS config for client: %w", err)
+		}
 
+		registryClient, err := registry.NewClient(
+			registry.ClientOptDebug(settings.Debug),
+			registry.ClientOptEnableCache(true),
+			registry.ClientOptWriter(logger.Writer()),
+			registry.ClientOptCredentialsFile(settings.RegistryConfig),
+			registry.ClientOptHTTPClient(&http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: tlsConf,
+					Proxy:           http.ProxyFromEnvironment,
+				},
+			}),
+			registry.ClientOptBasicAuth(username, password),
+		)
 		if err != nil {
 			return nil, err
 		}
 		return registryClient, nil
 	}
-	registryClient, err := newRegistryClient(settings, plainHTTP)
+	registryClient, err := newRegistryClient(settings, logger, plainHTTP, username, password)
 	if err != nil {
 		return nil, err
 	}
 	return registryClient, nil
 }
+
+// registryReported returns a field the registry client reported in its
+// output, like the digest of a pulled or pushed chart, empty if missing.
+func registryReported(output, field string) string {
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), field+": "); ok {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// newClientTLS builds the TLS config of the registry client, like helm's
+// internal tlsutil.NewClientTLS.
+func newClientTLS(certFile, keyFile, caFile string, insecureSkipTLSverify bool) (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: insecureSkipTLSverify,
+	}
+
+	if certFile != "" && keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load cert from key pair: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if caFile != "" {
+		ca, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't read CA file %q: %w", caFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to append certificates from file %q", caFile)
+		}
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
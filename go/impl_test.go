@@ -0,0 +1,876 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"helm.sh/helm/v3/pkg/release"
+)
+
+const testChart = "testdata/chart"
+
+func TestMain(m *testing.M) {
+	// Keep helm away from the user config, cache and data directories
+	home, err := os.MkdirTemp("", "helm-r2g")
+	if err != nil {
+		panic(err)
+	}
+	for _, env := range []string{"HELM_CACHE_HOME", "HELM_CONFIG_HOME", "HELM_DATA_HOME"} {
+		os.Setenv(env, filepath.Join(home, strings.ToLower(strings.TrimPrefix(env, "HELM_"))))
+	}
+
+	code := m.Run()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
+
+func testEnv(fakeKubeClient string) HelmEnv {
+	return HelmEnv{fake_kube_client: []string{fakeKubeClient}}
+}
+
+func installTestRelease(t *testing.T, ns, name string) InstallResponse {
+	t.Helper()
+
+	resp := Helm{}.install(&InstallRequest{
+		release_name: name,
+		chart:        testChart,
+		ns:           ns,
+		env:          testEnv(fakeKubePrinting),
+	})
+	if len(resp.err) > 0 {
+		t.Fatalf("install failed: %v", resp.err)
+	}
+
+	return resp
+}
+
+func upgradeTestRelease(t *testing.T, ns, name string, set ...string) UpgradeResponse {
+	t.Helper()
+
+	resp := Helm{}.upgrade(&UpgradeRequest{
+		release_name: name,
+		chart:        testChart,
+		ns:           ns,
+		set_values:   set,
+		env:          testEnv(fakeKubePrinting),
+	})
+	if len(resp.err) > 0 {
+		t.Fatalf("upgrade failed: %v", resp.err)
+	}
+
+	return resp
+}
+
+func TestInstall(t *testing.T) {
+	resp := installTestRelease(t, "install", "podinfo")
+
+	if len(resp.release) != 1 {
+		t.Fatalf("expected a release summary, got %d", len(resp.release))
+	}
+	summary := resp.release[0]
+	if summary.name != "podinfo" || summary.namespace != "install" || summary.revision != 1 {
+		t.Errorf("unexpected release %s/%s revision %d", summary.namespace, summary.name, summary.revision)
+	}
+	if summary.status != release.StatusDeployed.String() {
+		t.Errorf("expected status deployed, got %q", summary.status)
+	}
+	if summary.chart_name != "test" || summary.app_version != "1.0.0" {
+		t.Errorf("unexpected chart %q app version %q", summary.chart_name, summary.app_version)
+	}
+	if strings.TrimSpace(summary.notes) != "podinfo says hello" {
+		t.Errorf("unexpected notes %q", summary.notes)
+	}
+	if resp.revision != 1 {
+		t.Errorf("expected revision 1, got %d", resp.revision)
+	}
+
+	var rel release.Release
+	if err := json.Unmarshal([]byte(resp.data), &rel); err != nil {
+		t.Fatalf("failed to unmarshal release: %v", err)
+	}
+	if rel.Name != "podinfo" {
+		t.Errorf("unexpected release name %q in data", rel.Name)
+	}
+}
+
+func TestInstallValues(t *testing.T) {
+	resp := Helm{}.install(&InstallRequest{
+		release_name: "values",
+		chart:        testChart,
+		ns:           "install-values",
+		values:       []byte(`{"message":"blob","replicaCount":2}`),
+		set_values:   []string{"replicaCount=3"},
+		env:          testEnv(fakeKubePrinting),
+	})
+	if len(resp.err) > 0 {
+		t.Fatalf("install failed: %v", resp.err)
+	}
+
+	var rel release.Release
+	if err := json.Unmarshal([]byte(resp.data), &rel); err != nil {
+		t.Fatalf("failed to unmarshal release: %v", err)
+	}
+	if !strings.Contains(rel.Manifest, `message: "blob"`) || !strings.Contains(rel.Manifest, `replicaCount: "3"`) {
+		t.Errorf("values were not layered as expected:\n%s", rel.Manifest)
+	}
+}
+
+func TestInstallFailures(t *testing.T) {
+	installTestRelease(t, "install-failures", "exists")
+
+	tests := []struct {
+		name string
+		req  InstallRequest
+		kind string
+	}{
+		{
+			name: "release exists",
+			req:  InstallRequest{release_name: "exists", chart: testChart, env: testEnv(fakeKubePrinting)},
+			kind: errKindReleaseExists,
+		},
+		{
+			name: "chart not found",
+			req:  InstallRequest{release_name: "missing", chart: "testdata/missing", env: testEnv(fakeKubePrinting)},
+			kind: errKindChartNotFound,
+		},
+		{
+			name: "values schema",
+			req:  InstallRequest{release_name: "schema", chart: testChart, set_string_values: []string{"replicaCount=one"}, env: testEnv(fakeKubePrinting)},
+			kind: errKindChartInvalid,
+		},
+		{
+			name: "kube failure",
+			req:  InstallRequest{release_name: "failing", chart: testChart, env: testEnv(fakeKubeFailing)},
+			kind: errKindUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.ns = "install-failures"
+
+			resp := Helm{}.install(&tt.req)
+			if len(resp.err) == 0 {
+				t.Fatal("expected install to fail")
+			}
+			if resp.err_kind != tt.kind {
+				t.Errorf("expected error kind %q, got %q: %v", tt.kind, resp.err_kind, resp.err)
+			}
+		})
+	}
+}
+
+func TestInstallAtomic(t *testing.T) {
+	resp := Helm{}.install(&InstallRequest{
+		release_name: "atomic",
+		chart:        testChart,
+		ns:           "install-atomic",
+		atomic:       true,
+		env:          testEnv(fakeKubeFailing),
+	})
+	if len(resp.err) == 0 {
+		t.Fatal("expected install to fail")
+	}
+	if !resp.uninstalled {
+		t.Errorf("expected the failed release to be uninstalled: %v", resp.err)
+	}
+
+	history := Helm{}.history(&HistoryRequest{release_name: "atomic", ns: "install-atomic", env: testEnv(fakeKubePrinting)})
+	if history.err_kind != errKindReleaseNotFound {
+		t.Errorf("expected error kind %q, got %q: %v", errKindReleaseNotFound, history.err_kind, history.err)
+	}
+}
+
+// umbrellaChart writes a chart depending on the test chart, without the
+// dependency in its charts directory.
+func umbrellaChart(t *testing.T) string {
+	t.Helper()
+
+	dependency, err := filepath.Abs(testChart)
+	if err != nil {
+		t.Fatalf("failed to resolve the test chart: %v", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "umbrella")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatalf("failed to create the umbrella chart: %v", err)
+	}
+	metadata := `apiVersion: v2
+name: umbrella
+version: 0.1.0
+dependencies:
+- name: test
+  version: 0.1.0
+  repository: file://` + dependency + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte(metadata), 0o644); err != nil {
+		t.Fatalf("failed to write the umbrella chart: %v", err)
+	}
+
+	return dir
+}
+
+func TestInstallDependencyUpdate(t *testing.T) {
+	req := InstallRequest{
+		release_name: "umbrella",
+		chart:        umbrellaChart(t),
+		ns:           "install-dependency-update",
+		env:          testEnv(fakeKubePrinting),
+	}
+
+	resp := Helm{}.install(&req)
+	if resp.err_kind != errKindChartInvalid {
+		t.Errorf("expected error kind %q with a missing dependency, got %q: %v", errKindChartInvalid, resp.err_kind, resp.err)
+	}
+
+	req.dependency_update = true
+	resp = Helm{}.install(&req)
+	if len(resp.err) > 0 {
+		t.Fatalf("install failed: %v", resp.err)
+	}
+	if _, err := os.Stat(filepath.Join(req.chart, "charts", "test-0.1.0.tgz")); err != nil {
+		t.Errorf("expected the dependency to be downloaded: %v", err)
+	}
+}
+
+func TestUpgradeDependencyUpdate(t *testing.T) {
+	installTestRelease(t, "upgrade-dependency-update", "umbrella")
+
+	req := UpgradeRequest{
+		release_name: "umbrella",
+		chart:        umbrellaChart(t),
+		ns:           "upgrade-dependency-update",
+		env:          testEnv(fakeKubePrinting),
+	}
+
+	resp := Helm{}.upgrade(&req)
+	if resp.err_kind != errKindChartInvalid {
+		t.Errorf("expected error kind %q with a missing dependency, got %q: %v", errKindChartInvalid, resp.err_kind, resp.err)
+	}
+
+	req.dependency_update = true
+	resp = Helm{}.upgrade(&req)
+	if len(resp.err) > 0 {
+		t.Fatalf("upgrade failed: %v", resp.err)
+	}
+	if resp.revision != 2 {
+		t.Errorf("expected the upgrade to revision 2, got %d", resp.revision)
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	installTestRelease(t, "upgrade", "podinfo")
+
+	resp := upgradeTestRelease(t, "upgrade", "podinfo", "message=upgraded")
+	if resp.revision != 2 || len(resp.release) != 1 || resp.release[0].revision != 2 {
+		t.Fatalf("expected revision 2, got %d", resp.revision)
+	}
+	if strings.TrimSpace(resp.release[0].notes) != "podinfo says upgraded" {
+		t.Errorf("unexpected notes %q", resp.release[0].notes)
+	}
+}
+
+func TestUpgradeInstall(t *testing.T) {
+	resp := Helm{}.upgrade(&UpgradeRequest{
+		release_name: "podinfo",
+		chart:        testChart,
+		ns:           "upgrade-install",
+		install:      true,
+		env:          testEnv(fakeKubePrinting),
+	})
+	if len(resp.err) > 0 {
+		t.Fatalf("upgrade --install failed: %v", resp.err)
+	}
+	if resp.revision != 1 {
+		t.Errorf("expected the release to be installed, got revision %d", resp.revision)
+	}
+
+	resp = Helm{}.upgrade(&UpgradeRequest{
+		release_name: "podinfo",
+		chart:        testChart,
+		ns:           "upgrade-install",
+		install:      true,
+		env:          testEnv(fakeKubePrinting),
+	})
+	if len(resp.err) > 0 {
+		t.Fatalf("upgrade --install failed: %v", resp.err)
+	}
+	if resp.revision != 2 {
+		t.Errorf("expected the release to be upgraded, got revision %d", resp.revision)
+	}
+}
+
+func TestUpgradeInstallUninstalled(t *testing.T) {
+	installTestRelease(t, "upgrade-install-uninstalled", "podinfo")
+
+	uninstall := Helm{}.uninstall(&UninstallRequest{release_name: "podinfo", ns: "upgrade-install-uninstalled", keep_history: true, env: testEnv(fakeKubePrinting)})
+	if len(uninstall.err) > 0 {
+		t.Fatalf("uninstall failed: %v", uninstall.err)
+	}
+
+	resp := Helm{}.upgrade(&UpgradeRequest{
+		release_name: "podinfo",
+		chart:        testChart,
+		ns:           "upgrade-install-uninstalled",
+		install:      true,
+		env:          testEnv(fakeKubePrinting),
+	})
+	if len(resp.err) > 0 {
+		t.Fatalf("upgrade --install failed: %v", resp.err)
+	}
+	if resp.revision != 2 || len(resp.release) != 1 || resp.release[0].status != release.StatusDeployed.String() {
+		t.Errorf("expected the release to be installed again on revision 2, got %d: %+v", resp.revision, resp.release)
+	}
+}
+
+func TestUpgradeFailures(t *testing.T) {
+	resp := Helm{}.upgrade(&UpgradeRequest{
+		release_name: "missing",
+		chart:        testChart,
+		ns:           "upgrade-failures",
+		env:          testEnv(fakeKubePrinting),
+	})
+	if len(resp.err) == 0 {
+		t.Error("expected upgrading a missing release to fail")
+	}
+
+	installTestRelease(t, "upgrade-failures", "failing")
+
+	resp = Helm{}.upgrade(&UpgradeRequest{
+		release_name: "failing",
+		chart:        testChart,
+		ns:           "upgrade-failures",
+		env:          testEnv(fakeKubeFailing),
+	})
+	if len(resp.err) == 0 {
+		t.Fatal("expected upgrade to fail")
+	}
+	if resp.rolled_back || resp.revision != 2 {
+		t.Errorf("expected the failed revision 2 without rollback, got revision %d rolled back %t", resp.revision, resp.rolled_back)
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	resp := Helm{}.template(&TemplateRequest{chart: testChart, ns: "template"})
+	if len(resp.err) > 0 {
+		t.Fatalf("template failed: %v", resp.err)
+	}
+	if !strings.Contains(resp.manifest, "name: release-name\n") {
+		t.Errorf("manifest is missing the configmap:\n%s", resp.manifest)
+	}
+	if !strings.Contains(resp.hooks, "name: release-name-hook\n") {
+		t.Errorf("hooks are missing the hook:\n%s", resp.hooks)
+	}
+
+	resp = Helm{}.template(&TemplateRequest{chart: "testdata/missing"})
+	if len(resp.err) == 0 {
+		t.Fatal("expected template of a missing chart to fail")
+	}
+	if resp.err_kind != errKindChartNotFound {
+		t.Errorf("expected error kind %q, got %q", errKindChartNotFound, resp.err_kind)
+	}
+}
+
+func TestTemplateDependencyUpdate(t *testing.T) {
+	req := TemplateRequest{chart: umbrellaChart(t), ns: "template"}
+
+	resp := Helm{}.template(&req)
+	if resp.err_kind != errKindChartInvalid {
+		t.Errorf("expected error kind %q with a missing dependency, got %q: %v", errKindChartInvalid, resp.err_kind, resp.err)
+	}
+
+	req.dependency_update = true
+	resp = Helm{}.template(&req)
+	if len(resp.err) > 0 {
+		t.Fatalf("template failed: %v", resp.err)
+	}
+	if !strings.Contains(resp.manifest, "name: release-name\n") {
+		t.Errorf("manifest is missing the configmap of the dependency:\n%s", resp.manifest)
+	}
+}
+
+func TestList(t *testing.T) {
+	installTestRelease(t, "list", "first")
+	installTestRelease(t, "list", "second")
+
+	resp := Helm{}.list(&ListRequest{ns: "list", env: testEnv(fakeKubePrinting)})
+	if len(resp.err) > 0 {
+		t.Fatalf("list failed: %v", resp.err)
+	}
+	if len(resp.releases) != 2 || resp.releases[0].name != "first" || resp.releases[1].name != "second" {
+		t.Errorf("unexpected releases %+v", resp.releases)
+	}
+
+	Helm{}.uninstall(&UninstallRequest{release_name: "second", ns: "list", keep_history: true, env: testEnv(fakeKubePrinting)})
+
+	resp = Helm{}.list(&ListRequest{ns: "list", env: testEnv(fakeKubePrinting)})
+	if len(resp.releases) != 1 || resp.releases[0].name != "first" {
+		t.Errorf("expected only the deployed release, got %+v", resp.releases)
+	}
+
+	resp = Helm{}.list(&ListRequest{ns: "list", all: true, env: testEnv(fakeKubePrinting)})
+	if len(resp.releases) != 2 || resp.releases[1].status != release.StatusUninstalled.String() {
+		t.Errorf("expected all the releases, got %+v", resp.releases)
+	}
+
+	resp = Helm{}.list(&ListRequest{ns: "list", uninstalled: true, env: testEnv(fakeKubePrinting)})
+	if len(resp.releases) != 1 || resp.releases[0].name != "second" {
+		t.Errorf("expected only the uninstalled release, got %+v", resp.releases)
+	}
+
+	resp = Helm{}.list(&ListRequest{ns: "list", env: HelmEnv{driver: []string{"unknown"}, fake_kube_client: []string{fakeKubePrinting}}})
+	if len(resp.err) == 0 {
+		t.Error("expected list with an unknown driver to fail")
+	}
+}
+
+func TestMemoryDriverConcurrentNamespaces(t *testing.T) {
+	namespaces := []string{"concurrent-a", "concurrent-b"}
+
+	var wg sync.WaitGroup
+	errs := make(chan []string, len(namespaces)*4)
+	for _, ns := range namespaces {
+		for i := range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				resp := Helm{}.install(&InstallRequest{
+					release_name: fmt.Sprintf("podinfo-%d", i),
+					chart:        testChart,
+					ns:           ns,
+					env:          testEnv(fakeKubePrinting),
+				})
+				errs <- resp.err
+			}()
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if len(err) > 0 {
+			t.Fatalf("install failed: %v", err)
+		}
+	}
+
+	for _, ns := range namespaces {
+		resp := Helm{}.list(&ListRequest{ns: ns, env: testEnv(fakeKubePrinting)})
+		if len(resp.releases) != 4 {
+			t.Errorf("expected 4 releases in %s, got %+v", ns, resp.releases)
+		}
+		for _, rls := range resp.releases {
+			if rls.namespace != ns {
+				t.Errorf("unexpected release %s in %s", rls.name, rls.namespace)
+			}
+		}
+	}
+
+	resp := Helm{}.list(&ListRequest{all_namespaces: true, env: testEnv(fakeKubePrinting)})
+	found := 0
+	for _, rls := range resp.releases {
+		if slices.Contains(namespaces, rls.namespace) {
+			found++
+		}
+	}
+	if found != 8 {
+		t.Errorf("expected the 8 releases across namespaces, got %d", found)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	installTestRelease(t, "status", "podinfo")
+
+	resp := Helm{}.status(&StatusRequest{release_name: "podinfo", ns: "status", env: testEnv(fakeKubePrinting)})
+	if len(resp.err) > 0 {
+		t.Fatalf("status failed: %v", resp.err)
+	}
+	if resp.data == "" {
+		t.Error("expected the release in data")
+	}
+
+	resp = Helm{}.status(&StatusRequest{release_name: "missing", ns: "status", env: testEnv(fakeKubePrinting)})
+	if resp.err_kind != errKindReleaseNotFound {
+		t.Errorf("expected error kind %q, got %q: %v", errKindReleaseNotFound, resp.err_kind, resp.err)
+	}
+}
+
+func TestGet(t *testing.T) {
+	installTestRelease(t, "get", "podinfo")
+
+	resp := Helm{}.get(&GetRequest{release_name: "podinfo", ns: "get", what: getAllValues, env: testEnv(fakeKubePrinting)})
+	if len(resp.err) > 0 {
+		t.Fatalf("get failed: %v", resp.err)
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(resp.json, &values); err != nil {
+		t.Fatalf("failed to unmarshal values: %v", err)
+	}
+	if values["message"] != "hello" {
+		t.Errorf("unexpected values %v", values)
+	}
+
+	resp = Helm{}.get(&GetRequest{release_name: "podinfo", ns: "get", what: getManifest, env: testEnv(fakeKubePrinting)})
+	if !strings.Contains(resp.text, "kind: ConfigMap") {
+		t.Errorf("unexpected manifest %q", resp.text)
+	}
+
+	resp = Helm{}.get(&GetRequest{release_name: "podinfo", ns: "get", what: "unknown", env: testEnv(fakeKubePrinting)})
+	if len(resp.err) == 0 {
+		t.Error("expected get of an unknown output to fail")
+	}
+}
+
+func TestHistory(t *testing.T) {
+	installTestRelease(t, "history", "podinfo")
+	upgradeTestRelease(t, "history", "podinfo")
+
+	resp := Helm{}.history(&HistoryRequest{release_name: "podinfo", ns: "history", env: testEnv(fakeKubePrinting)})
+	if len(resp.err) > 0 {
+		t.Fatalf("history failed: %v", resp.err)
+	}
+	var releases []*release.Release
+	if err := json.Unmarshal([]byte(resp.data), &releases); err != nil {
+		t.Fatalf("failed to unmarshal history: %v", err)
+	}
+	if len(releases) != 2 {
+		t.Errorf("expected 2 revisions, got %d", len(releases))
+	}
+
+	resp = Helm{}.history(&HistoryRequest{release_name: "missing", ns: "history", env: testEnv(fakeKubePrinting)})
+	if resp.err_kind != errKindReleaseNotFound {
+		t.Errorf("expected error kind %q, got %q: %v", errKindReleaseNotFound, resp.err_kind, resp.err)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	installTestRelease(t, "rollback", "podinfo")
+	upgradeTestRelease(t, "rollback", "podinfo")
+
+	resp := Helm{}.rollback(&RollbackRequest{release_name: "podinfo", ns: "rollback", revision: 1, env: testEnv(fakeKubePrinting)})
+	if len(resp.err) > 0 {
+		t.Fatalf("rollback failed: %v", resp.err)
+	}
+	var rel release.Release
+	if err := json.Unmarshal([]byte(resp.data), &rel); err != nil {
+		t.Fatalf("failed to unmarshal release: %v", err)
+	}
+	if rel.Version != 3 {
+		t.Errorf("expected revision 3, got %d", rel.Version)
+	}
+	if len(resp.release) != 1 || resp.release[0].revision != 3 {
+		t.Errorf("unexpected release %+v", resp.release)
+	}
+
+	resp = Helm{}.rollback(&RollbackRequest{release_name: "missing", ns: "rollback", env: testEnv(fakeKubePrinting)})
+	if len(resp.err) == 0 {
+		t.Error("expected rollback of a missing release to fail")
+	}
+}
+
+// waitForFakeWait waits until an operation waits on the waiting kube client.
+func waitForFakeWait(t *testing.T) {
+	t.Helper()
+
+	select {
+	case <-fakeWaits:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the operation never waited for its resources")
+	}
+}
+
+func TestRollbackCancelled(t *testing.T) {
+	id := testOperationID(t)
+	installTestRelease(t, "rollback-cancelled", "podinfo")
+	upgradeTestRelease(t, "rollback-cancelled", "podinfo")
+
+	rollback := make(chan RollbackResponse)
+	go func() {
+		rollback <- Helm{}.rollback(&RollbackRequest{
+			release_name: "podinfo",
+			ns:           "rollback-cancelled",
+			revision:     1,
+			wait:         true,
+			timeout:      []int64{60},
+			id:           id,
+			env:          testEnv(fakeKubeWaiting),
+		})
+	}()
+
+	waitForFakeWait(t)
+	Helm{}.cancel(&CancelRequest{id: id})
+
+	resp := <-rollback
+	if !resp.cancelled || resp.err_kind != errKindCancelled {
+		t.Errorf("expected the rollback to be cancelled, got %q: %v", resp.err_kind, resp.err)
+	}
+}
+
+func TestUninstall(t *testing.T) {
+	installTestRelease(t, "uninstall", "podinfo")
+
+	resp := Helm{}.uninstall(&UninstallRequest{release_name: "podinfo", ns: "uninstall", env: testEnv(fakeKubePrinting)})
+	if len(resp.err) > 0 {
+		t.Fatalf("uninstall failed: %v", resp.err)
+	}
+	if len(resp.release) != 1 || resp.release[0].name != "podinfo" {
+		t.Errorf("unexpected release %+v", resp.release)
+	}
+
+	resp = Helm{}.uninstall(&UninstallRequest{release_name: "podinfo", ns: "uninstall", env: testEnv(fakeKubePrinting)})
+	if resp.err_kind != errKindReleaseNotFound {
+		t.Errorf("expected error kind %q, got %q: %v", errKindReleaseNotFound, resp.err_kind, resp.err)
+	}
+}
+
+// lastOperationID is the last id handed out by testOperationID.
+var lastOperationID atomic.Uint64
+
+// testOperationID returns an operation id no other test uses, the cancel and
+// events registries are global to the process. Its entries are removed once
+// the test is done.
+func testOperationID(t *testing.T) uint64 {
+	id := 1000 + lastOperationID.Add(1)
+	t.Cleanup(func() {
+		operations.Lock()
+		delete(operations.running, id)
+		delete(operations.cancelled, id)
+		operations.Unlock()
+
+		streams.Lock()
+		delete(streams.byID, id)
+		streams.Unlock()
+	})
+
+	return id
+}
+
+func TestEvents(t *testing.T) {
+	id := testOperationID(t)
+	resp := Helm{}.install(&InstallRequest{
+		release_name: "events",
+		chart:        testChart,
+		ns:           "events",
+		id:           id,
+		events:       true,
+		env:          testEnv(fakeKubePrinting),
+	})
+	if len(resp.err) > 0 {
+		t.Fatalf("install failed: %v", resp.err)
+	}
+
+	var phases []string
+	for {
+		events := Helm{}.next_events(&EventsRequest{id: id})
+		for _, e := range events.events {
+			if e.kind == eventPhase {
+				phases = append(phases, e.phase)
+			}
+		}
+		if events.done {
+			break
+		}
+	}
+
+	if len(phases) == 0 || phases[0] != phaseLocating {
+		t.Errorf("unexpected phases %v", phases)
+	}
+}
+
+// drainEvents polls the events of the request until the stream is done,
+// failing if it is still open after a few polls.
+func drainEvents(t *testing.T, id uint64) {
+	t.Helper()
+
+	for range 5 {
+		events := Helm{}.next_events(&EventsRequest{id: id})
+		if events.done {
+			return
+		}
+	}
+	t.Errorf("expected the events of request %d to be done", id)
+}
+
+func TestEventsEarlyFailure(t *testing.T) {
+	id := testOperationID(t)
+	Helm{}.open_events(&EventsRequest{id: id})
+	resp := Helm{}.install(&InstallRequest{
+		release_name: "events",
+		chart:        testChart,
+		ns:           "events-failure",
+		set_values:   []string{"invalid"},
+		id:           id,
+		events:       true,
+		env:          testEnv(fakeKubePrinting),
+	})
+	if len(resp.err) == 0 {
+		t.Fatal("expected an invalid --set to fail")
+	}
+	drainEvents(t, id)
+
+	installTestRelease(t, "events-failure", "locked")
+	settings := initSettings(testEnv(fakeKubePrinting), "events-failure")
+	unlock, _, err := lockRelease(context.Background(), releaseKeyOf(settings, "locked"), 0)
+	if err != nil {
+		t.Fatalf("failed to lock the release: %v", err)
+	}
+	defer unlock()
+
+	lockedID := testOperationID(t)
+	Helm{}.open_events(&EventsRequest{id: lockedID})
+	upgrade := Helm{}.upgrade(&UpgradeRequest{
+		release_name: "locked",
+		chart:        testChart,
+		ns:           "events-failure",
+		lock_timeout: []int64{0},
+		id:           lockedID,
+		events:       true,
+		env:          testEnv(fakeKubePrinting),
+	})
+	if upgrade.err_kind != errKindLocked {
+		t.Fatalf("expected error kind %q, got %q: %v", errKindLocked, upgrade.err_kind, upgrade.err)
+	}
+	drainEvents(t, lockedID)
+
+	// Drained and unknown streams are done right away
+	drainEvents(t, lockedID)
+	drainEvents(t, testOperationID(t))
+}
+
+func TestCancel(t *testing.T) {
+	id := testOperationID(t)
+	Helm{}.cancel(&CancelRequest{id: id})
+
+	resp := Helm{}.install(&InstallRequest{
+		release_name: "cancelled",
+		chart:        testChart,
+		ns:           "cancel",
+		id:           id,
+		env:          testEnv(fakeKubePrinting),
+	})
+	if !resp.cancelled || resp.err_kind != errKindCancelled {
+		t.Errorf("expected the install to be cancelled, got %v", resp.err)
+	}
+}
+
+func TestLogs(t *testing.T) {
+	installTestRelease(t, "logs", "podinfo")
+
+	var printed strings.Builder
+	log.SetOutput(&printed)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	resp := Helm{}.upgrade(&UpgradeRequest{
+		release_name: "podinfo",
+		chart:        testChart,
+		ns:           "logs",
+		env:          HelmEnv{fake_kube_client: []string{fakeKubePrinting}, debug: true},
+	})
+	if len(resp.err) > 0 {
+		t.Fatalf("upgrade failed: %v", resp.err)
+	}
+	if len(resp.logs) == 0 || !strings.HasPrefix(resp.logs[0], debugPrefix) {
+		t.Errorf("expected debug logs, got %v", resp.logs)
+	}
+	if printed.Len() > 0 {
+		t.Errorf("expected the lines to only be captured, got %q printed", printed.String())
+	}
+
+	resp = Helm{}.upgrade(&UpgradeRequest{
+		release_name: "podinfo",
+		chart:        testChart,
+		ns:           "logs",
+		env:          testEnv(fakeKubePrinting),
+	})
+	if len(resp.err) > 0 {
+		t.Fatalf("upgrade failed: %v", resp.err)
+	}
+	for _, line := range resp.logs {
+		if strings.HasPrefix(line, debugPrefix) {
+			t.Errorf("unexpected debug line %q", line)
+		}
+	}
+}
+
+func TestRepoAddAndSearch(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HELM_REPOSITORY_CONFIG", filepath.Join(dir, "repositories.yaml"))
+	t.Setenv("HELM_REPOSITORY_CACHE", filepath.Join(dir, "cache"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/index.yaml" {
+			http.NotFound(w, r)
+
+			return
+		}
+		w.Write([]byte(`apiVersion: v1
+entries:
+  podinfo:
+  - name: podinfo
+    version: 6.5.4
+    appVersion: 6.5.4
+    description: Podinfo Helm chart for Kubernetes
+    urls:
+    - podinfo-6.5.4.tgz
+`))
+	}))
+	defer server.Close()
+
+	add := Helm{}.repo_add(&AddRequest{name: "test", url: server.URL})
+	if len(add.err) > 0 {
+		t.Fatalf("repo add failed: %v", add.err)
+	}
+
+	search := Helm{}.repo_search(&SearchRequest{terms: []string{"podinfo"}})
+	if len(search.err) > 0 {
+		t.Fatalf("repo search failed: %v", search.err)
+	}
+	if !strings.Contains(search.data, "test/podinfo") {
+		t.Errorf("expected test/podinfo in the results, got %s", search.data)
+	}
+
+	add = Helm{}.repo_add(&AddRequest{name: "missing", url: server.URL + "/missing"})
+	if len(add.err) == 0 {
+		t.Error("expected adding a repo without index to fail")
+	}
+}
+
+func TestRegistryLogin(t *testing.T) {
+	t.Setenv("HELM_REGISTRY_CONFIG", filepath.Join(t.TempDir(), "config.json"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "user" || password != "password" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	resp := Helm{}.registry_login(&LoginRequest{hostname: host, username: "user", password: "password", plain_http: true})
+	if len(resp.err) > 0 {
+		t.Fatalf("registry login failed: %v", resp.err)
+	}
+
+	resp = Helm{}.registry_login(&LoginRequest{hostname: host, username: "user", password: "wrong", plain_http: true})
+	if len(resp.err) == 0 {
+		t.Fatal("expected registry login with a wrong password to fail")
+	}
+	if resp.err_kind != errKindAuth {
+		t.Errorf("expected error kind %q, got %q: %v", errKindAuth, resp.err_kind, resp.err)
+	}
+}
@@ -2,16 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
-	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/downloader"
 	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
 )
 
 type install struct {
@@ -23,14 +26,37 @@ type install struct {
 	CreateNamespace bool
 	DryRunOption    []string
 	Values          map[string]interface{}
+
+	Atomic      bool
+	WaitForJobs bool
+
+	// DependencyUpdate updates the dependencies missing from charts/
+	// before installing
+	DependencyUpdate bool
+	ChartSource      chartSource
+
+	// Replace reuses the name of an uninstalled release whose history was
+	// kept
+	Replace bool
+}
+
+// recovery describes what helm's failure handling did to a release after a
+// failed install or upgrade, and which revision it was left on.
+type recovery struct {
+	RolledBack  bool
+	Uninstalled bool
+	// Revision is 0 when the release no longer exists
+	Revision int
 }
 
-func runInstall(ctx context.Context, logger *log.Logger, settings *cli.EnvSettings, install install) (*release.Release, error) {
+func runInstall(ctx context.Context, logger *log.Logger, settings *helmSettings, install install) (*release.Release, recovery, error) {
 	actionConfig, err := initActionConfig(settings, logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to init action config: %w", err)
+		return nil, recovery{}, fmt.Errorf("failed to init action config: %w", err)
 	}
 
+	events := eventsFrom(ctx)
+
 	installClient := action.NewInstall(actionConfig)
 
 	installClient.DryRunOption = "none"
@@ -39,69 +65,117 @@ func runInstall(ctx context.Context, logger *log.Logger, settings *cli.EnvSettin
 	installClient.ReleaseName = install.ReleaseName
 	chartRef := install.ChartRef
 	installClient.Wait = install.Wait
+	installClient.WaitForJobs = install.WaitForJobs
+	installClient.Atomic = install.Atomic
 	installClient.Timeout = time.Duration(install.Timeout) * time.Second
 	installClient.CreateNamespace = install.CreateNamespace
 	installClient.Namespace = settings.Namespace()
 	installClient.Version = install.ChartVersion
+	installClient.DependencyUpdate = install.DependencyUpdate
+	installClient.Replace = install.Replace
 
-	registryClient, err := newRegistryClientTLS(
-		settings,
-		logger,
-		installClient.CertFile,
-		installClient.KeyFile,
-		installClient.CaFile,
-		installClient.InsecureSkipTLSverify,
-		installClient.PlainHTTP)
+	install.ChartSource.apply(&installClient.ChartPathOptions)
+
+	registryClient, err := install.ChartSource.registryClient(settings, logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to created registry client: %w", err)
+		return nil, recovery{}, fmt.Errorf("failed to created registry client: %w", err)
 	}
 	installClient.SetRegistryClient(registryClient)
 
-	chartPath, err := installClient.ChartPathOptions.LocateChart(chartRef, settings)
+	events.enter(phaseLocating)
+	chartPath, err := installClient.ChartPathOptions.LocateChart(chartRef, settings.EnvSettings)
+	if err != nil {
+		return nil, recovery{}, withKind(errKindChartNotFound, fmt.Errorf("failed to locate chart: %w", err))
+	}
+
+	chart, err := prepareChart(ctx, logger, settings, chartPath, install.DependencyUpdate, installClient.Keyring, registryClient)
+	if err != nil {
+		return nil, recovery{}, err
+	}
+
+	// Locating and loading the chart can take a while, don't start the release
+	// if the request was cancelled in the meantime
+	if err := ctx.Err(); err != nil {
+		return nil, recovery{}, fmt.Errorf("install cancelled: %w", err)
+	}
+
+	events.enter(phaseRendering)
+	release, err := installClient.RunWithContext(ctx, chart, install.Values)
 	if err != nil {
-		return nil, fmt.Errorf("failed to locate chart: %w", err)
+		return nil, releaseRecovery(actionConfig, install.ReleaseName, install.Atomic, release), fmt.Errorf("failed to run install: %w", err)
 	}
 
-	providers := getter.All(settings)
+	return release, recovery{Revision: release.Version}, nil
+}
+
+// prepareChart loads the chart at chartPath and checks that its dependencies
+// are present in charts/, updating them first when dependencyUpdate is set.
+func prepareChart(ctx context.Context, logger *log.Logger, settings *helmSettings, chartPath string, dependencyUpdate bool, keyring string, registryClient *registry.Client) (*chart.Chart, error) {
+	events := eventsFrom(ctx)
 
+	events.enter(phaseLoading)
 	chart, err := loader.Load(chartPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load chart: %w", err)
+		return nil, withKind(errKindChartInvalid, fmt.Errorf("failed to load chart: %w", err))
+	}
+
+	chartDependencies := chart.Metadata.Dependencies
+	if chartDependencies == nil {
+		return chart, nil
 	}
 
-	// Check chart dependencies to make sure all are present in /charts
-	if chartDependencies := chart.Metadata.Dependencies; chartDependencies != nil {
-		if err := action.CheckDependencies(chart, chartDependencies); err != nil {
-			err = fmt.Errorf("failed to check chart dependencies: %w", err)
-			if !installClient.DependencyUpdate {
-				return nil, err
-			}
-
-			manager := &downloader.Manager{
-				Out:              logger.Writer(),
-				ChartPath:        chartPath,
-				Keyring:          installClient.ChartPathOptions.Keyring,
-				SkipUpdate:       false,
-				Getters:          providers,
-				RepositoryConfig: settings.RepositoryConfig,
-				RepositoryCache:  settings.RepositoryCache,
-				Debug:            settings.Debug,
-				RegistryClient:   installClient.GetRegistryClient(),
-			}
-			if err := manager.Update(); err != nil {
-				return nil, fmt.Errorf("failed to update chart dependencies: %w", err)
-			}
-			// Reload the chart with the updated Chart.lock file.
-			if chart, err = loader.Load(chartPath); err != nil {
-				return nil, fmt.Errorf("failed to reload chart after repo update: %w", err)
-			}
+	if err := action.CheckDependencies(chart, chartDependencies); err != nil {
+		err = withKind(errKindChartInvalid, fmt.Errorf("failed to check chart dependencies: %w", err))
+		if !dependencyUpdate {
+			return nil, err
+		}
+
+		events.enter(phaseDependencies)
+		if err := updateDependencies(logger, settings, chartPath, keyring, registryClient); err != nil {
+			return nil, err
+		}
+		// Reload the chart with the updated Chart.lock file.
+		if chart, err = loader.Load(chartPath); err != nil {
+			return nil, withKind(errKindChartInvalid, fmt.Errorf("failed to reload chart after repo update: %w", err))
 		}
 	}
 
-	release, err := installClient.RunWithContext(ctx, chart, install.Values)
+	return chart, nil
+}
+
+// updateDependencies downloads the dependencies of the chart at chartPath
+// into its charts/ directory, like `helm dependency update`.
+func updateDependencies(logger *log.Logger, settings *helmSettings, chartPath, keyring string, registryClient *registry.Client) error {
+	manager := &downloader.Manager{
+		Out:              logger.Writer(),
+		ChartPath:        chartPath,
+		Keyring:          keyring,
+		SkipUpdate:       false,
+		Getters:          getter.All(settings.EnvSettings),
+		RepositoryConfig: settings.RepositoryConfig,
+		RepositoryCache:  settings.RepositoryCache,
+		Debug:            settings.Debug,
+		RegistryClient:   registryClient,
+	}
+	if err := manager.Update(); err != nil {
+		return fmt.Errorf("failed to update chart dependencies: %w", err)
+	}
+
+	return nil
+}
+
+// releaseRecovery inspects the release storage after failed, the revision
+// returned by the failed action, to find out whether an atomic install was
+// uninstalled or an atomic upgrade was rolled back.
+func releaseRecovery(actionConfig *action.Configuration, name string, atomic bool, failed *release.Release) recovery {
+	last, err := actionConfig.Releases.Last(name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to run install: %w", err)
+		// Atomic installs uninstall the release without keeping its history
+		return recovery{Uninstalled: atomic && failed != nil && errors.Is(err, driver.ErrReleaseNotFound)}
 	}
 
-	return release, nil
+	return recovery{
+		RolledBack: atomic && failed != nil && last.Version > failed.Version && last.Info.Status == release.StatusDeployed,
+		Revision:   last.Version,
+	}
 }
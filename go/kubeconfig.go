@@ -0,0 +1,255 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"helm.sh/helm/v3/pkg/kube"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// inlineContext names the cluster, user and context built from the inline
+// credentials when there is no kubeconfig.
+const inlineContext = "inline"
+
+// kubeCredentials are the cluster credentials passed in memory, so the
+// workers don't have to write them to disk.
+type kubeCredentials struct {
+	// Config is an inline kubeconfig
+	Config []byte
+	// CAData is the PEM encoded certificate authority of the api server
+	CAData []byte
+	// CertData and KeyData are the PEM encoded client certificate and key
+	CertData []byte
+	KeyData  []byte
+	// InCluster uses the service account of the pod
+	InCluster bool
+}
+
+func (c kubeCredentials) empty() bool {
+	return len(c.Config) == 0 && len(c.CAData) == 0 && len(c.CertData) == 0 && len(c.KeyData) == 0 && !c.InCluster
+}
+
+// RESTClientGetter returns the getter of the inline credentials when there
+// are any, and the one of the helm settings otherwise.
+func (s *helmSettings) RESTClientGetter() genericclioptions.RESTClientGetter {
+	if s.Credentials.empty() {
+		return s.EnvSettings.RESTClientGetter()
+	}
+
+	return &inlineRESTClientGetter{settings: s}
+}
+
+// Namespace returns the namespace of the request, or the one of the inline
+// kubeconfig, without reading the kubeconfig files.
+func (s *helmSettings) Namespace() string {
+	if s.Credentials.empty() || s.namespace != "" {
+		return s.EnvSettings.Namespace()
+	}
+
+	if namespace, _, err := s.RESTClientGetter().ToRawKubeConfigLoader().Namespace(); err == nil && namespace != "" {
+		return namespace
+	}
+
+	return "default"
+}
+
+// inlineRESTClientGetter builds the clients from the inline credentials, and
+// caches the discovery in memory instead of the helm cache directory.
+type inlineRESTClientGetter struct {
+	settings *helmSettings
+
+	mu        sync.Mutex
+	discovery discovery.CachedDiscoveryInterface
+	mapper    meta.RESTMapper
+}
+
+var _ genericclioptions.RESTClientGetter = &inlineRESTClientGetter{}
+
+// ToRESTConfig returns the rest config tuned like the helm settings do.
+func (g *inlineRESTClientGetter) ToRESTConfig() (*rest.Config, error) {
+	config, err := g.ToRawKubeConfigLoader().ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	config.Burst = g.settings.BurstLimit
+	config.QPS = g.settings.QPS
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &kube.RetryingRoundTripper{Wrapped: rt}
+	})
+
+	return config, nil
+}
+
+func (g *inlineRESTClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.discoveryClient()
+}
+
+func (g *inlineRESTClientGetter) discoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	if g.discovery != nil {
+		return g.discovery, nil
+	}
+
+	config, err := g.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the discovery client: %w", err)
+	}
+
+	g.discovery = memory.NewMemCacheClient(client)
+
+	return g.discovery, nil
+}
+
+func (g *inlineRESTClientGetter) ToRESTMapper() (meta.RESTMapper, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.mapper != nil {
+		return g.mapper, nil
+	}
+
+	client, err := g.discoveryClient()
+	if err != nil {
+		return nil, err
+	}
+
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(client)
+	g.mapper = restmapper.NewShortcutExpander(mapper, client, nil)
+
+	return g.mapper, nil
+}
+
+func (g *inlineRESTClientGetter) ToRawKubeConfigLoader() clientcmd.ClientConfig {
+	config, err := g.rawConfig()
+	if err != nil {
+		return errClientConfig{err: err}
+	}
+
+	return clientcmd.NewNonInteractiveClientConfig(*config, g.settings.KubeContext, g.overrides(), nil)
+}
+
+// rawConfig returns the inline kubeconfig, the in cluster config, or an empty
+// config the overrides are applied to.
+func (g *inlineRESTClientGetter) rawConfig() (*clientcmdapi.Config, error) {
+	credentials := g.settings.Credentials
+
+	switch {
+	case len(credentials.Config) > 0:
+		config, err := clientcmd.Load(credentials.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the inline kubeconfig: %w", err)
+		}
+
+		return config, nil
+	case credentials.InCluster:
+		return inClusterConfig()
+	default:
+		return singleContextConfig(&clientcmdapi.Cluster{}, &clientcmdapi.AuthInfo{}, ""), nil
+	}
+}
+
+// overrides applies the helm settings and the inline credentials on top of
+// the kubeconfig.
+func (g *inlineRESTClientGetter) overrides() *clientcmd.ConfigOverrides {
+	settings := g.settings
+	credentials := settings.Credentials
+
+	overrides := &clientcmd.ConfigOverrides{}
+	overrides.Context.Namespace = settings.namespace
+	overrides.ClusterInfo.Server = settings.KubeAPIServer
+	overrides.ClusterInfo.CertificateAuthority = settings.KubeCaFile
+	overrides.ClusterInfo.CertificateAuthorityData = credentials.CAData
+	overrides.ClusterInfo.InsecureSkipTLSVerify = settings.KubeInsecureSkipTLSVerify
+	overrides.ClusterInfo.TLSServerName = settings.KubeTLSServerName
+	overrides.AuthInfo.Token = settings.KubeToken
+	overrides.AuthInfo.ClientCertificateData = credentials.CertData
+	overrides.AuthInfo.ClientKeyData = credentials.KeyData
+	overrides.AuthInfo.Impersonate = settings.KubeAsUser
+	overrides.AuthInfo.ImpersonateGroups = settings.KubeAsGroups
+
+	return overrides
+}
+
+// inClusterConfig returns a kubeconfig pointing to the service account files
+// of the pod, rest.InClusterConfig does the same but can't be overridden.
+func inClusterConfig() (*clientcmdapi.Config, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, rest.ErrNotInCluster
+	}
+
+	namespace, err := os.ReadFile(filepath.Join(serviceAccountDir, "namespace"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read the service account namespace: %w", err)
+	}
+
+	cluster := &clientcmdapi.Cluster{
+		Server:               "https://" + net.JoinHostPort(host, port),
+		CertificateAuthority: filepath.Join(serviceAccountDir, "ca.crt"),
+	}
+	user := &clientcmdapi.AuthInfo{
+		TokenFile: filepath.Join(serviceAccountDir, "token"),
+	}
+
+	return singleContextConfig(cluster, user, strings.TrimSpace(string(namespace))), nil
+}
+
+// serviceAccountDir is where the service account of a pod is mounted.
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+func singleContextConfig(cluster *clientcmdapi.Cluster, user *clientcmdapi.AuthInfo, namespace string) *clientcmdapi.Config {
+	config := clientcmdapi.NewConfig()
+	config.Clusters[inlineContext] = cluster
+	config.AuthInfos[inlineContext] = user
+	config.Contexts[inlineContext] = &clientcmdapi.Context{
+		Cluster:   inlineContext,
+		AuthInfo:  inlineContext,
+		Namespace: namespace,
+	}
+	config.CurrentContext = inlineContext
+
+	return config
+}
+
+// errClientConfig reports the error of an inline kubeconfig that can't be
+// loaded from every ClientConfig method.
+type errClientConfig struct {
+	err error
+}
+
+func (c errClientConfig) RawConfig() (clientcmdapi.Config, error) {
+	return clientcmdapi.Config{}, c.err
+}
+
+func (c errClientConfig) ClientConfig() (*rest.Config, error) {
+	return nil, c.err
+}
+
+func (c errClientConfig) Namespace() (string, bool, error) {
+	return "", false, c.err
+}
+
+func (c errClientConfig) ConfigAccess() clientcmd.ConfigAccess {
+	return clientcmd.NewDefaultClientConfigLoadingRules()
+}
@@ -0,0 +1,127 @@
+package main
+
+import "testing"
+
+const testKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com
+- name: prod
+  cluster:
+    server: https://prod.example.com
+users:
+- name: dev
+  user:
+    token: dev-token
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: dev
+    namespace: dev-apps
+- name: prod
+  context:
+    cluster: prod
+    user: dev
+current-context: dev
+`
+
+func TestInlineKubeConfig(t *testing.T) {
+	settings := initSettings(HelmEnv{kube_config_data: []byte(testKubeConfig)}, "")
+
+	config, err := settings.RESTClientGetter().ToRESTConfig()
+	if err != nil {
+		t.Fatalf("failed to build the rest config: %v", err)
+	}
+	if config.Host != "https://dev.example.com" || config.BearerToken != "dev-token" {
+		t.Errorf("unexpected host %q and token %q", config.Host, config.BearerToken)
+	}
+	if namespace := settings.Namespace(); namespace != "dev-apps" {
+		t.Errorf("expected the namespace of the context, got %q", namespace)
+	}
+
+	settings = initSettings(HelmEnv{
+		kube_config_data: []byte(testKubeConfig),
+		kube_context:     []string{"prod"},
+		kube_token:       []string{"prod-token"},
+	}, "apps")
+
+	config, err = settings.RESTClientGetter().ToRESTConfig()
+	if err != nil {
+		t.Fatalf("failed to build the rest config: %v", err)
+	}
+	if config.Host != "https://prod.example.com" || config.BearerToken != "prod-token" {
+		t.Errorf("unexpected host %q and token %q", config.Host, config.BearerToken)
+	}
+	if namespace := settings.Namespace(); namespace != "apps" {
+		t.Errorf("expected the namespace of the request, got %q", namespace)
+	}
+}
+
+func TestInlineCredentials(t *testing.T) {
+	settings := initSettings(HelmEnv{
+		kube_api_server:       []string{"https://api.example.com:6443"},
+		kube_ca_data:          []byte("ca"),
+		kube_client_cert_data: []byte("cert"),
+		kube_client_key_data:  []byte("key"),
+	}, "")
+
+	config, err := settings.RESTClientGetter().ToRESTConfig()
+	if err != nil {
+		t.Fatalf("failed to build the rest config: %v", err)
+	}
+	if config.Host != "https://api.example.com:6443" {
+		t.Errorf("unexpected host %q", config.Host)
+	}
+	if string(config.CAData) != "ca" || string(config.CertData) != "cert" || string(config.KeyData) != "key" {
+		t.Errorf("unexpected tls config %+v", config.TLSClientConfig)
+	}
+	if namespace := settings.Namespace(); namespace != "default" {
+		t.Errorf("expected the default namespace, got %q", namespace)
+	}
+}
+
+func TestInlineCredentialsErrors(t *testing.T) {
+	settings := initSettings(HelmEnv{kube_config_data: []byte("clusters: [")}, "")
+	if _, err := settings.RESTClientGetter().ToRESTConfig(); err == nil {
+		t.Error("expected an invalid kubeconfig to fail")
+	}
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	settings = initSettings(HelmEnv{kube_in_cluster: true}, "")
+	if _, err := settings.RESTClientGetter().ToRESTConfig(); err == nil {
+		t.Error("expected the in cluster config to fail outside of a cluster")
+	}
+}
+
+func TestClientTuning(t *testing.T) {
+	env := HelmEnv{
+		kube_config_data:     []byte(testKubeConfig),
+		kube_as_user:         []string{"tenant"},
+		kube_as_groups:       []string{"tenants", "deployers"},
+		kube_tls_server_name: []string{"api.internal"},
+		burst_limit:          []int64{500},
+		qps:                  []float32{250},
+	}
+
+	config, err := initSettings(env, "").RESTClientGetter().ToRESTConfig()
+	if err != nil {
+		t.Fatalf("failed to build the rest config: %v", err)
+	}
+	if config.Impersonate.UserName != "tenant" || len(config.Impersonate.Groups) != 2 {
+		t.Errorf("unexpected impersonation %+v", config.Impersonate)
+	}
+	if config.ServerName != "api.internal" {
+		t.Errorf("unexpected tls server name %q", config.ServerName)
+	}
+	if config.Burst != 500 || config.QPS != 250 {
+		t.Errorf("unexpected burst %d and qps %f", config.Burst, config.QPS)
+	}
+
+	settings := initSettings(HelmEnv{burst_limit: []int64{500}, qps: []float32{250}}, "")
+	if settings.BurstLimit != 500 || settings.QPS != 250 {
+		t.Errorf("unexpected settings burst %d and qps %f", settings.BurstLimit, settings.QPS)
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// defaultPageSize is used when the request does not set a page size.
+const defaultPageSize = 100
+
+// Sort orders of the paged list, they are part of the cursor so a cursor
+// can't be reused with another order
+const (
+	pageSortName     = "name"
+	pageSortNameDesc = "name_desc"
+	pageSortDate     = "date"
+	pageSortDateDesc = "date_desc"
+)
+
+type listPage struct {
+	AllNamespaces bool
+	All           bool
+	StateMask     action.ListStates
+	Deployed      bool
+	Failed        bool
+	Pending       bool
+	Uninstalled   bool
+	Uninstalling  bool
+	Superseded    bool
+	Filter        string
+	Selector      string
+	ByDate        bool
+	SortReverse   bool
+	PageSize      int
+	// Cursor is the continuation token of the previous page, empty for the
+	// first one
+	Cursor string
+}
+
+// listCursor is the position of the last release of a page. The releases are
+// sorted on a total order, so the next page starts right after it even if
+// releases were added or removed in the meantime. The revision only breaks
+// ties between the revisions of a release listed with the superseded ones.
+type listCursor struct {
+	Sort      string `json:"s"`
+	Namespace string `json:"ns"`
+	Name      string `json:"n"`
+	Revision  int    `json:"r"`
+	// Deployed is the last deployment time in unix nanoseconds, it is only
+	// set for the date orders
+	Deployed int64 `json:"d,omitempty"`
+}
+
+func (l listPage) sort() string {
+	switch {
+	case l.ByDate && l.SortReverse:
+		return pageSortDate
+	case l.ByDate:
+		// Like helm, the date order lists the latest releases first
+		return pageSortDateDesc
+	case l.SortReverse:
+		return pageSortNameDesc
+	default:
+		return pageSortName
+	}
+}
+
+func cursorOf(sort string, rel *release.Release) listCursor {
+	cursor := listCursor{
+		Sort:      sort,
+		Namespace: rel.Namespace,
+		Name:      rel.Name,
+		Revision:  rel.Version,
+	}
+	if (sort == pageSortDate || sort == pageSortDateDesc) && rel.Info != nil {
+		cursor.Deployed = rel.Info.LastDeployed.UnixNano()
+	}
+
+	return cursor
+}
+
+// compare orders the cursors according to their sort.
+func (c listCursor) compare(other listCursor) int {
+	var order int
+	switch c.Sort {
+	case pageSortDate, pageSortDateDesc:
+		order = cmp.Or(
+			cmp.Compare(c.Deployed, other.Deployed),
+			cmp.Compare(c.Namespace, other.Namespace),
+			cmp.Compare(c.Name, other.Name),
+			cmp.Compare(c.Revision, other.Revision),
+		)
+	default:
+		order = cmp.Or(
+			cmp.Compare(c.Name, other.Name),
+			cmp.Compare(c.Namespace, other.Namespace),
+			cmp.Compare(c.Revision, other.Revision),
+		)
+	}
+
+	if c.Sort == pageSortNameDesc || c.Sort == pageSortDateDesc {
+		return -order
+	}
+
+	return order
+}
+
+func (c listCursor) encode() string {
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(token, sort string) (*listCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var cursor listCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	if cursor.Sort != sort {
+		return nil, fmt.Errorf("invalid cursor: it was issued for the %s order, not %s", cursor.Sort, sort)
+	}
+
+	return &cursor, nil
+}
+
+// runListPage returns the releases after the cursor, and the cursor of the
+// next page, empty on the last page.
+func runListPage(logger *log.Logger, settings *helmSettings, listPage listPage) ([]*release.Release, string, error) {
+	sort := listPage.sort()
+
+	after, err := decodeCursor(listPage.Cursor, sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	actionConfig, err := initActionConfigList(settings, logger, listPage.AllNamespaces)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to init action config: %w", err)
+	}
+
+	listClient := action.NewList(actionConfig)
+	listClient.AllNamespaces = listPage.AllNamespaces
+	listClient.All = listPage.All
+	listClient.Deployed = listPage.Deployed
+	listClient.Failed = listPage.Failed
+	listClient.Pending = listPage.Pending
+	listClient.Uninstalled = listPage.Uninstalled
+	listClient.Uninstalling = listPage.Uninstalling
+	listClient.Superseded = listPage.Superseded
+	listClient.Filter = listPage.Filter
+	listClient.Selector = listPage.Selector
+
+	listClient.SetStateMask()
+	if listPage.StateMask != 0 && !listClient.All {
+		listClient.StateMask = listPage.StateMask
+	}
+
+	releases, err := runList(listClient)
+	if err != nil {
+		return nil, "", err
+	}
+
+	slices.SortFunc(releases, func(a, b *release.Release) int {
+		return cursorOf(sort, a).compare(cursorOf(sort, b))
+	})
+
+	if after != nil {
+		start, found := slices.BinarySearchFunc(releases, *after, func(rel *release.Release, after listCursor) int {
+			return cursorOf(sort, rel).compare(after)
+		})
+		// Skip the last release of the previous page if it is still there
+		if found {
+			start++
+		}
+		releases = releases[start:]
+	}
+
+	pageSize := listPage.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if len(releases) <= pageSize {
+		return releases, "", nil
+	}
+
+	releases = releases[:pageSize]
+
+	return releases, cursorOf(sort, releases[len(releases)-1]).encode(), nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/release"
+)
+
+func listTestPage(t *testing.T, req ListPageRequest) ListPageResponse {
+	t.Helper()
+
+	req.env = testEnv(fakeKubePrinting)
+	resp := Helm{}.list_page(&req)
+	if len(resp.err) > 0 {
+		t.Fatalf("list page failed: %v", resp.err)
+	}
+
+	return resp
+}
+
+func TestListPage(t *testing.T) {
+	for i := range 5 {
+		installTestRelease(t, "list-page", fmt.Sprintf("release-%d", i))
+	}
+
+	var names []string
+	cursor := ""
+	for page := 0; ; page++ {
+		resp := listTestPage(t, ListPageRequest{ns: "list-page", page_size: 2, cursor: cursor})
+		if len(resp.releases) > 2 {
+			t.Fatalf("expected at most 2 releases, got %d", len(resp.releases))
+		}
+		for _, summary := range resp.releases {
+			names = append(names, summary.name)
+		}
+
+		// A release listed before the cursor does not shift the next pages
+		if page == 0 {
+			installTestRelease(t, "list-page", "release-0a")
+		}
+
+		if resp.next_cursor == "" {
+			break
+		}
+		cursor = resp.next_cursor
+	}
+
+	expected := []string{"release-0", "release-1", "release-2", "release-3", "release-4"}
+	if fmt.Sprint(names) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+
+	resp := listTestPage(t, ListPageRequest{ns: "list-page", sort_reverse: true, page_size: 2})
+	if len(resp.releases) != 2 || resp.releases[0].name != "release-4" || resp.next_cursor == "" {
+		t.Fatalf("unexpected reversed page %+v", resp.releases)
+	}
+
+	mismatch := Helm{}.list_page(&ListPageRequest{ns: "list-page", cursor: resp.next_cursor, env: testEnv(fakeKubePrinting)})
+	if len(mismatch.err) == 0 {
+		t.Error("expected a cursor of another order to fail")
+	}
+
+	invalid := Helm{}.list_page(&ListPageRequest{ns: "list-page", cursor: "not a cursor", env: testEnv(fakeKubePrinting)})
+	if len(invalid.err) == 0 {
+		t.Error("expected an invalid cursor to fail")
+	}
+}
+
+func TestListPageAll(t *testing.T) {
+	installTestRelease(t, "list-page-all", "deployed")
+	installTestRelease(t, "list-page-all", "uninstalled")
+	Helm{}.uninstall(&UninstallRequest{release_name: "uninstalled", ns: "list-page-all", keep_history: true, env: testEnv(fakeKubePrinting)})
+
+	resp := listTestPage(t, ListPageRequest{ns: "list-page-all"})
+	if len(resp.releases) != 1 || resp.releases[0].name != "deployed" || resp.next_cursor != "" {
+		t.Errorf("expected only the deployed release, got %+v", resp.releases)
+	}
+
+	resp = listTestPage(t, ListPageRequest{ns: "list-page-all", all: true})
+	if len(resp.releases) != 2 || resp.releases[1].status != release.StatusUninstalled.String() {
+		t.Errorf("expected all the releases, got %+v", resp.releases)
+	}
+}
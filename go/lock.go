@@ -0,0 +1,129 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// errReleaseLocked is returned when the release is locked by another call
+// and the request did not wait for it.
+var errReleaseLocked = errors.New("another operation on the release is in progress in this process")
+
+// releaseKey identifies a release across the clusters handled by the process.
+type releaseKey struct {
+	cluster   string
+	namespace string
+	name      string
+}
+
+func releaseKeyOf(settings *helmSettings, name string) releaseKey {
+	// The host is the same for every credential of a cluster, the kubeconfig
+	// and context are only used when the config can't be loaded
+	cluster := settings.KubeConfig + "/" + settings.KubeContext
+	if config, err := restClientGetter(settings).ToRESTConfig(); err == nil {
+		cluster = config.Host
+	}
+
+	return releaseKey{
+		cluster:   cmp.Or(settings.FakeKubeClient, cluster),
+		namespace: settings.Namespace(),
+		name:      name,
+	}
+}
+
+type releaseLock struct {
+	// held has a value while the lock is held
+	held chan struct{}
+	// refs counts the holder and the waiters, the lock is forgotten when
+	// it drops to zero
+	refs int
+}
+
+// releaseLocks serializes the install, upgrade, rollback and uninstall calls
+// on a release, helm would fail them with "another operation is in progress"
+// and may leave the release pending.
+var releaseLocks = struct {
+	sync.Mutex
+	byKey map[releaseKey]*releaseLock
+}{
+	byKey: map[releaseKey]*releaseLock{},
+}
+
+// lockRelease locks the release, waiting up to timeout for the current
+// holder. A zero timeout fails fast, a negative one waits until the context
+// is done. It returns the unlock func and how long it waited.
+func lockRelease(ctx context.Context, key releaseKey, timeout time.Duration) (func(), time.Duration, error) {
+	releaseLocks.Lock()
+	lock, ok := releaseLocks.byKey[key]
+	if !ok {
+		lock = &releaseLock{held: make(chan struct{}, 1)}
+		releaseLocks.byKey[key] = lock
+	}
+	lock.refs++
+	releaseLocks.Unlock()
+
+	release := func() {
+		releaseLocks.Lock()
+		defer releaseLocks.Unlock()
+
+		lock.refs--
+		if lock.refs == 0 {
+			delete(releaseLocks.byKey, key)
+		}
+	}
+
+	start := time.Now()
+
+	select {
+	case lock.held <- struct{}{}:
+		return func() {
+			<-lock.held
+			release()
+		}, 0, nil
+	default:
+		if timeout == 0 {
+			release()
+
+			return nil, 0, fmt.Errorf("failed to lock release %s/%s: %w", key.namespace, key.name, errReleaseLocked)
+		}
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	select {
+	case lock.held <- struct{}{}:
+		return func() {
+			<-lock.held
+			release()
+		}, time.Since(start), nil
+	case <-ctx.Done():
+		release()
+
+		err := ctx.Err()
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = errReleaseLocked
+		}
+
+		waited := time.Since(start)
+
+		return nil, waited, fmt.Errorf("failed to lock release %s/%s after %s: %w", key.namespace, key.name, waited.Round(time.Millisecond), err)
+	}
+}
+
+// lockTimeout converts the lock timeout of a request, unset waits until the
+// request is cancelled.
+func lockTimeout(seconds []int64) time.Duration {
+	if len(seconds) == 0 {
+		return -1
+	}
+
+	return time.Duration(get(seconds)) * time.Second
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLockRelease(t *testing.T) {
+	key := releaseKey{cluster: "test", namespace: "lock", name: "podinfo"}
+
+	unlock, waited, err := lockRelease(context.Background(), key, -1)
+	if err != nil || waited != 0 {
+		t.Fatalf("expected the lock to be free, waited %s: %v", waited, err)
+	}
+
+	if _, _, err := lockRelease(context.Background(), key, 0); errorKind(err) != errKindLocked {
+		t.Errorf("expected fail fast with kind %q, got %v", errKindLocked, err)
+	}
+
+	if _, waited, err := lockRelease(context.Background(), key, time.Second/10); errorKind(err) != errKindLocked || waited < time.Second/10 {
+		t.Errorf("expected a timeout with kind %q after waiting, waited %s: %v", errKindLocked, waited, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := lockRelease(ctx, key, -1); errorKind(err) != errKindCancelled {
+		t.Errorf("expected the wait to be cancelled, got %v", err)
+	}
+
+	other := releaseKey{cluster: "test", namespace: "lock", name: "other"}
+	unlockOther, _, err := lockRelease(context.Background(), other, 0)
+	if err != nil {
+		t.Fatalf("expected another release not to be locked: %v", err)
+	}
+	unlockOther()
+
+	go func() {
+		time.Sleep(time.Second / 10)
+		unlock()
+	}()
+
+	unlock, waited, err = lockRelease(context.Background(), key, time.Minute)
+	if err != nil {
+		t.Fatalf("expected the lock once released: %v", err)
+	}
+	if waited < time.Second/20 {
+		t.Errorf("expected to wait for the holder, waited %s", waited)
+	}
+	unlock()
+
+	releaseLocks.Lock()
+	defer releaseLocks.Unlock()
+	if len(releaseLocks.byKey) != 0 {
+		t.Errorf("expected the locks to be forgotten, got %d", len(releaseLocks.byKey))
+	}
+}
+
+func TestUpgradeLocked(t *testing.T) {
+	installTestRelease(t, "upgrade-locked", "podinfo")
+
+	settings := initSettings(testEnv(fakeKubePrinting), "upgrade-locked")
+	unlock, _, err := lockRelease(context.Background(), releaseKeyOf(settings, "podinfo"), 0)
+	if err != nil {
+		t.Fatalf("failed to lock the release: %v", err)
+	}
+	defer unlock()
+
+	resp := Helm{}.upgrade(&UpgradeRequest{
+		release_name: "podinfo",
+		chart:        testChart,
+		ns:           "upgrade-locked",
+		lock_timeout: []int64{0},
+		env:          testEnv(fakeKubePrinting),
+	})
+	if resp.err_kind != errKindLocked {
+		t.Errorf("expected error kind %q, got %q: %v", errKindLocked, resp.err_kind, resp.err)
+	}
+}
+
+func TestRollbackLockCancelled(t *testing.T) {
+	rollbackID, recoverID := testOperationID(t), testOperationID(t)
+	installTestRelease(t, "rollback-locked", "podinfo")
+	upgradeTestRelease(t, "rollback-locked", "podinfo")
+
+	settings := initSettings(testEnv(fakeKubePrinting), "rollback-locked")
+	unlock, _, err := lockRelease(context.Background(), releaseKeyOf(settings, "podinfo"), 0)
+	if err != nil {
+		t.Fatalf("failed to lock the release: %v", err)
+	}
+	defer unlock()
+
+	rollback := make(chan RollbackResponse)
+	go func() {
+		rollback <- Helm{}.rollback(&RollbackRequest{release_name: "podinfo", ns: "rollback-locked", revision: 1, id: rollbackID, env: testEnv(fakeKubePrinting)})
+	}()
+	recovered := make(chan RecoverResponse)
+	go func() {
+		recovered <- Helm{}.recover_release(&RecoverRequest{release_name: "podinfo", ns: "rollback-locked", id: recoverID, env: testEnv(fakeKubePrinting)})
+	}()
+	Helm{}.cancel(&CancelRequest{id: rollbackID})
+	Helm{}.cancel(&CancelRequest{id: recoverID})
+
+	if resp := <-rollback; !resp.cancelled || resp.err_kind != errKindCancelled {
+		t.Errorf("expected the rollback to be cancelled, got %v", resp.err)
+	}
+	if resp := <-recovered; !resp.cancelled || resp.err_kind != errKindCancelled {
+		t.Errorf("expected the recover to be cancelled, got %v", resp.err)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+type logLevel int
+
+const (
+	levelDebug logLevel = iota
+	levelInfo
+	levelWarning
+	levelError
+)
+
+// debugPrefix marks the helm action log lines, like `helm --debug` does.
+const debugPrefix = "[debug] "
+
+func parseLogLevel(level string) (logLevel, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return levelDebug, nil
+	case "", "info":
+		return levelInfo, nil
+	case "warn", "warning":
+		return levelWarning, nil
+	case "error":
+		return levelError, nil
+	default:
+		return levelInfo, fmt.Errorf("unknown log level %q", level)
+	}
+}
+
+// lineLevel guesses the level of a log line, helm only logs plain strings.
+func lineLevel(line string) logLevel {
+	lower := strings.ToLower(line)
+
+	switch {
+	case strings.HasPrefix(line, debugPrefix):
+		return levelDebug
+	case strings.HasPrefix(lower, "warning"):
+		return levelWarning
+	case strings.HasPrefix(lower, "error"):
+		return levelError
+	default:
+		return levelInfo
+	}
+}
+
+// requestLogger captures the log lines of a single request at or above its
+// level, so they can be returned along with the response. The lines are not
+// printed to the process logger, where the concurrent requests would
+// interleave them.
+type requestLogger struct {
+	mu    sync.Mutex
+	level logLevel
+	lines []string
+}
+
+// newRequestLogger returns a logger with the level of the env, the debug
+// switch lowers it to debug.
+func newRequestLogger(env HelmEnv) *requestLogger {
+	level, err := parseLogLevel(get(env.log_level))
+	if env.debug {
+		level = levelDebug
+	}
+
+	logger := &requestLogger{level: level}
+	if err != nil {
+		logger.logger().Printf("warning: %s, using info", err)
+	}
+
+	return logger
+}
+
+func (l *requestLogger) Write(p []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		if line == "" || lineLevel(line) < l.level {
+			continue
+		}
+
+		l.mu.Lock()
+		l.lines = append(l.lines, line)
+		l.mu.Unlock()
+	}
+
+	return len(p), nil
+}
+
+// logger returns a standard logger writing to l.
+func (l *requestLogger) logger() *log.Logger {
+	return log.New(l, "", 0)
+}
+
+// captured returns the lines logged so far.
+func (l *requestLogger) captured() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]string(nil), l.lines...)
+}
+
+// debugLog returns the log func used by the helm actions, the lines are
+// marked as debug so the request logger can filter them.
+func debugLog(logger *log.Logger) func(string, ...interface{}) {
+	return func(format string, v ...interface{}) {
+		logger.Printf(debugPrefix+format, v...)
+	}
+}
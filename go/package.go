@@ -0,0 +1,77 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"helm.sh/helm/v3/pkg/action"
+)
+
+type packageChart struct {
+	// Path is the chart directory
+	Path string
+	// Destination is where the archive is written, it defaults to the
+	// current directory
+	Destination string
+	// Version and AppVersion override the ones of Chart.yaml
+	Version          string
+	AppVersion       string
+	DependencyUpdate bool
+
+	// Sign writes a provenance file next to the archive, signed with Key
+	// from Keyring, the PassphraseFile unlocks the key
+	Sign           bool
+	Key            string
+	Keyring        string
+	PassphraseFile string
+}
+
+// runPackage archives the chart directory and returns the archive path.
+func runPackage(logger *log.Logger, settings *helmSettings, packageChart packageChart) (string, error) {
+	path, err := filepath.Abs(packageChart.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve chart path: %w", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", withKind(errKindChartNotFound, fmt.Errorf("failed to find chart: %w", err))
+	}
+
+	packageClient := action.NewPackage()
+	packageClient.Destination = cmp.Or(packageChart.Destination, ".")
+	packageClient.Version = packageChart.Version
+	packageClient.AppVersion = packageChart.AppVersion
+	packageClient.DependencyUpdate = packageChart.DependencyUpdate
+	packageClient.RepositoryConfig = settings.RepositoryConfig
+	packageClient.RepositoryCache = settings.RepositoryCache
+
+	packageClient.Sign = packageChart.Sign
+	packageClient.Key = packageChart.Key
+	packageClient.Keyring = cmp.Or(packageChart.Keyring, defaultKeyring())
+	packageClient.PassphraseFile = packageChart.PassphraseFile
+	if packageClient.Sign && packageClient.Key == "" {
+		return "", errors.New("a key is required for signing a package")
+	}
+
+	if packageClient.DependencyUpdate {
+		registryClient, err := newRegistryClient(settings, logger, false, "", "")
+		if err != nil {
+			return "", fmt.Errorf("failed to created registry client: %w", err)
+		}
+
+		if err := updateDependencies(logger, settings, path, packageClient.Keyring, registryClient); err != nil {
+			return "", err
+		}
+	}
+
+	archive, err := packageClient.Run(path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to package chart: %w", err)
+	}
+	logger.Printf("Successfully packaged chart and saved it to: %s", archive)
+
+	return archive, nil
+}
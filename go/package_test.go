@@ -0,0 +1,69 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart/loader"
+)
+
+func TestPackage(t *testing.T) {
+	dest := t.TempDir()
+
+	resp := Helm{}.package_chart(&PackageRequest{
+		path:        testChart,
+		destination: dest,
+		version:     "1.2.3",
+		app_version: "2.0.0",
+	})
+	if len(resp.err) > 0 {
+		t.Fatalf("package failed: %v", resp.err)
+	}
+	if resp.path != filepath.Join(dest, "test-1.2.3.tgz") {
+		t.Errorf("unexpected archive %q", resp.path)
+	}
+
+	chart, err := loader.Load(resp.path)
+	if err != nil {
+		t.Fatalf("failed to load the archive: %v", err)
+	}
+	if chart.Metadata.Version != "1.2.3" || chart.Metadata.AppVersion != "2.0.0" {
+		t.Errorf("expected the overridden versions, got %s and %s", chart.Metadata.Version, chart.Metadata.AppVersion)
+	}
+}
+
+func TestPackageDependencyUpdate(t *testing.T) {
+	resp := Helm{}.package_chart(&PackageRequest{
+		path:              umbrellaChart(t),
+		destination:       t.TempDir(),
+		dependency_update: true,
+	})
+	if len(resp.err) > 0 {
+		t.Fatalf("package failed: %v", resp.err)
+	}
+
+	chart, err := loader.Load(resp.path)
+	if err != nil {
+		t.Fatalf("failed to load the archive: %v", err)
+	}
+	if len(chart.Dependencies()) != 1 || chart.Dependencies()[0].Name() != "test" {
+		t.Errorf("expected the test dependency in the archive, got %d dependencies", len(chart.Dependencies()))
+	}
+}
+
+func TestPackageFailures(t *testing.T) {
+	resp := Helm{}.package_chart(&PackageRequest{path: filepath.Join(t.TempDir(), "missing"), destination: t.TempDir()})
+	if resp.err_kind != errKindChartNotFound {
+		t.Errorf("expected error kind %q, got %q: %v", errKindChartNotFound, resp.err_kind, resp.err)
+	}
+
+	resp = Helm{}.package_chart(&PackageRequest{path: testChart, destination: t.TempDir(), version: "not semver"})
+	if len(resp.err) == 0 {
+		t.Error("expected an invalid version to fail")
+	}
+
+	resp = Helm{}.package_chart(&PackageRequest{path: testChart, destination: t.TempDir(), sign: true})
+	if len(resp.err) == 0 {
+		t.Error("expected signing without a key to fail")
+	}
+}
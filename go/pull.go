@@ -0,0 +1,143 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+type pull struct {
+	ChartRef string
+	Version  string
+	// DestDir is where the chart is saved, it defaults to the current
+	// directory
+	DestDir string
+	// Untar expands the chart into UntarDir, relative to DestDir, instead of
+	// saving the archive
+	Untar    bool
+	UntarDir string
+	// Prov also saves the provenance file, without verifying it
+	Prov  bool
+	Devel bool
+
+	ChartSource chartSource
+}
+
+// pulled describes where runPull saved the chart.
+type pulled struct {
+	// Path is the chart archive, or the chart directory when untarred
+	Path string
+	// Digest is the manifest digest of charts pulled from an OCI registry
+	Digest string
+}
+
+func runPull(logger *log.Logger, settings *helmSettings, pull pull) (pulled, error) {
+	// The registry client reports the digest of the pulled manifest
+	var output strings.Builder
+	registryLogger := log.New(io.MultiWriter(logger.Writer(), &output), logger.Prefix(), logger.Flags())
+
+	registryClient, err := pull.ChartSource.registryClient(settings, registryLogger)
+	if err != nil {
+		return pulled{}, fmt.Errorf("failed to created registry client: %w", err)
+	}
+
+	// Pulling a chart does not talk to the cluster
+	pullClient := action.NewPullWithOpts(action.WithConfig(new(action.Configuration)))
+	pullClient.SetRegistryClient(registryClient)
+	pullClient.Settings = settings.EnvSettings
+	pull.ChartSource.apply(&pullClient.ChartPathOptions)
+
+	pullClient.Version = pull.Version
+	if pullClient.Version == "" && pull.Devel {
+		pullClient.Version = ">0.0.0-0"
+	}
+	pullClient.Devel = pull.Devel
+	pullClient.VerifyLater = pull.Prov
+
+	destDir := cmp.Or(pull.DestDir, ".")
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return pulled{}, fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	// Helm doesn't return the path of the chart, so it is downloaded alone in
+	// a staging directory and moved or expanded from there
+	staging, err := os.MkdirTemp(destDir, ".pull-")
+	if err != nil {
+		return pulled{}, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+	pullClient.DestDir = staging
+
+	out, err := pullClient.Run(pull.ChartRef)
+	if out != "" {
+		logger.Print(strings.TrimSuffix(out, "\n"))
+	}
+	if err != nil {
+		return pulled{}, withKind(errKindChartNotFound, fmt.Errorf("failed to pull chart: %w", err))
+	}
+
+	archives, err := filepath.Glob(filepath.Join(staging, "*.tgz"))
+	if err != nil || len(archives) != 1 {
+		return pulled{}, fmt.Errorf("failed to find the pulled chart in %s", staging)
+	}
+
+	result := pulled{Digest: registryReported(output.String(), "Digest")}
+
+	if pull.Untar {
+		result.Path, err = untarChart(archives[0], destDir, pull.UntarDir)
+		if err != nil {
+			return pulled{}, err
+		}
+
+		return result, nil
+	}
+
+	files, err := os.ReadDir(staging)
+	if err != nil {
+		return pulled{}, fmt.Errorf("failed to read staging directory: %w", err)
+	}
+	for _, file := range files {
+		if err := os.Rename(filepath.Join(staging, file.Name()), filepath.Join(destDir, file.Name())); err != nil {
+			return pulled{}, fmt.Errorf("failed to save %s: %w", file.Name(), err)
+		}
+	}
+	result.Path = filepath.Join(destDir, filepath.Base(archives[0]))
+
+	return result, nil
+}
+
+// untarChart expands the archive into untarDir, relative to destDir, and
+// returns the chart directory. Like helm, it doesn't overwrite an existing
+// chart.
+func untarChart(archive, destDir, untarDir string) (string, error) {
+	chart, err := loader.Load(archive)
+	if err != nil {
+		return "", withKind(errKindChartInvalid, fmt.Errorf("failed to load chart: %w", err))
+	}
+
+	untarDir = cmp.Or(untarDir, ".")
+	if !filepath.IsAbs(untarDir) {
+		untarDir = filepath.Join(destDir, untarDir)
+	}
+
+	chartDir := filepath.Join(untarDir, chart.Name())
+	if _, err := os.Stat(chartDir); err == nil {
+		return "", fmt.Errorf("failed to untar: a file or directory with the name %s already exists", chartDir)
+	}
+	if err := os.MkdirAll(untarDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to untar (mkdir): %w", err)
+	}
+	if err := chartutil.ExpandFile(untarDir, archive); err != nil {
+		return "", fmt.Errorf("failed to untar: %w", err)
+	}
+
+	return chartDir, nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPull(t *testing.T) {
+	url := chartRepository(t, "")
+	dest := t.TempDir()
+
+	req := PullRequest{
+		chart:    "test",
+		repo_url: url,
+		username: "user",
+		password: "secret",
+		dest_dir: dest,
+	}
+
+	resp := Helm{}.pull(&req)
+	if len(resp.err) > 0 {
+		t.Fatalf("pull failed: %v", resp.err)
+	}
+	if resp.path != filepath.Join(dest, "test-0.1.0.tgz") || resp.digest != "" {
+		t.Errorf("unexpected pulled chart %q with digest %q", resp.path, resp.digest)
+	}
+	if _, err := os.Stat(resp.path); err != nil {
+		t.Errorf("expected the chart archive to be saved: %v", err)
+	}
+
+	req.untar = true
+	req.untar_dir = "charts"
+	resp = Helm{}.pull(&req)
+	if len(resp.err) > 0 {
+		t.Fatalf("pull with untar failed: %v", resp.err)
+	}
+	if resp.path != filepath.Join(dest, "charts", "test") {
+		t.Errorf("unexpected untarred chart %q", resp.path)
+	}
+	if _, err := os.Stat(filepath.Join(resp.path, "Chart.yaml")); err != nil {
+		t.Errorf("expected the chart to be expanded: %v", err)
+	}
+
+	resp = Helm{}.pull(&req)
+	if len(resp.err) == 0 {
+		t.Error("expected untarring over an existing chart to fail")
+	}
+
+	entries, err := os.ReadDir(dest)
+	if err != nil {
+		t.Fatalf("failed to read the destination: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("expected only the archive and the charts directory, got %d entries", len(entries))
+	}
+}
+
+func TestPullFailures(t *testing.T) {
+	url := chartRepository(t, "")
+
+	resp := Helm{}.pull(&PullRequest{chart: "test", repo_url: url, dest_dir: t.TempDir()})
+	if resp.err_kind != errKindAuth {
+		t.Errorf("expected error kind %q without credentials, got %q: %v", errKindAuth, resp.err_kind, resp.err)
+	}
+
+	resp = Helm{}.pull(&PullRequest{chart: "missing", repo_url: url, username: "user", password: "secret", dest_dir: t.TempDir()})
+	if resp.err_kind != errKindChartNotFound {
+		t.Errorf("expected error kind %q, got %q: %v", errKindChartNotFound, resp.err_kind, resp.err)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+type push struct {
+	// ChartPath is the chart archive, a provenance file next to it is
+	// pushed along
+	ChartPath string
+	// Remote is the oci:// repository the chart is pushed under
+	Remote    string
+	CertFile  string
+	KeyFile   string
+	CaFile    string
+	Insecure  bool
+	PlainHTTP bool
+}
+
+// pushed describes the chart runPush uploaded.
+type pushed struct {
+	// Ref is the reference of the chart, without the oci:// scheme
+	Ref    string
+	Digest string
+}
+
+func runPush(logger *log.Logger, settings *helmSettings, push push) (pushed, error) {
+	if !registry.IsOCI(push.Remote) {
+		return pushed{}, fmt.Errorf("invalid remote %q: only oci:// registries are supported", push.Remote)
+	}
+	if _, err := os.Stat(push.ChartPath); err != nil {
+		return pushed{}, withKind(errKindChartNotFound, fmt.Errorf("failed to find chart archive: %w", err))
+	}
+
+	// The registry client reports the reference and digest of the pushed
+	// manifest
+	var output strings.Builder
+	registryLogger := log.New(io.MultiWriter(logger.Writer(), &output), logger.Prefix(), logger.Flags())
+
+	registryClient, err := newRegistryClientTLS(
+		settings,
+		registryLogger,
+		push.CertFile,
+		push.KeyFile,
+		push.CaFile,
+		push.Insecure,
+		push.PlainHTTP,
+		"",
+		"")
+	if err != nil {
+		return pushed{}, fmt.Errorf("failed to created registry client: %w", err)
+	}
+
+	// Pushing a chart does not talk to the cluster
+	actionConfig := new(action.Configuration)
+	actionConfig.RegistryClient = registryClient
+
+	pushClient := action.NewPushWithOpts(
+		action.WithPushConfig(actionConfig),
+		action.WithTLSClientConfig(push.CertFile, push.KeyFile, push.CaFile),
+		action.WithInsecureSkipTLSVerify(push.Insecure),
+		action.WithPlainHTTP(push.PlainHTTP),
+		action.WithPushOptWriter(logger.Writer()))
+	pushClient.Settings = settings.EnvSettings
+
+	out, err := pushClient.Run(push.ChartPath, push.Remote)
+	if out != "" {
+		logger.Print(strings.TrimSuffix(out, "\n"))
+	}
+	if err != nil {
+		return pushed{}, fmt.Errorf("failed to push chart: %w", err)
+	}
+
+	return pushed{
+		Ref:    registryReported(output.String(), "Pushed"),
+		Digest: registryReported(output.String(), "Digest"),
+	}, nil
+}
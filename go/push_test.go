@@ -0,0 +1,209 @@
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// ociRoute matches the distribution API paths, repository names can have
+// slashes
+var ociRoute = regexp.MustCompile(`^/v2/(.+)/(blobs/uploads|blobs|manifests|tags)/?([^/]*)$`)
+
+// ociRegistry is an in-memory stand-in for a registry:2 server, it supports
+// what helm needs to push and pull charts.
+type ociRegistry struct {
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	types     map[string]string
+	tags      map[string]map[string]string
+	uploads   int
+}
+
+// newOCIRegistry starts the registry and returns its host.
+func newOCIRegistry(t *testing.T) string {
+	t.Helper()
+
+	registry := &ociRegistry{
+		blobs:     map[string][]byte{},
+		manifests: map[string][]byte{},
+		types:     map[string]string{},
+		tags:      map[string]map[string]string{},
+	}
+	server := httptest.NewServer(registry)
+	t.Cleanup(server.Close)
+
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func ociDigest(data []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+}
+
+func (r *ociRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if req.URL.Path == "/v2/" || req.URL.Path == "/v2" {
+		return
+	}
+
+	route := ociRoute.FindStringSubmatch(req.URL.Path)
+	if route == nil {
+		http.NotFound(w, req)
+
+		return
+	}
+	name, kind, ref := route[1], route[2], route[3]
+
+	switch {
+	case kind == "blobs/uploads" && req.Method == http.MethodPost:
+		r.uploads++
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%d", name, r.uploads))
+		w.WriteHeader(http.StatusAccepted)
+	case kind == "blobs/uploads" && req.Method == http.MethodPut:
+		data, _ := io.ReadAll(req.Body)
+		digest := req.URL.Query().Get("digest")
+		if digest != ociDigest(data) {
+			http.Error(w, "digest mismatch", http.StatusBadRequest)
+
+			return
+		}
+		r.blobs[digest] = data
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/%s", name, digest))
+		w.WriteHeader(http.StatusCreated)
+	case kind == "blobs":
+		data, ok := r.blobs[ref]
+		if !ok {
+			http.NotFound(w, req)
+
+			return
+		}
+		w.Header().Set("Docker-Content-Digest", ref)
+		w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+		if req.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case kind == "manifests" && req.Method == http.MethodPut:
+		data, _ := io.ReadAll(req.Body)
+		digest := ociDigest(data)
+		r.manifests[digest] = data
+		r.types[digest] = req.Header.Get("Content-Type")
+		if !strings.HasPrefix(ref, "sha256:") {
+			if r.tags[name] == nil {
+				r.tags[name] = map[string]string{}
+			}
+			r.tags[name][ref] = digest
+		}
+		w.Header().Set("Docker-Content-Digest", digest)
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/manifests/%s", name, digest))
+		w.WriteHeader(http.StatusCreated)
+	case kind == "manifests":
+		digest := ref
+		if tagged, ok := r.tags[name][ref]; ok {
+			digest = tagged
+		}
+		data, ok := r.manifests[digest]
+		if !ok {
+			http.NotFound(w, req)
+
+			return
+		}
+		w.Header().Set("Content-Type", r.types[digest])
+		w.Header().Set("Docker-Content-Digest", digest)
+		w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+		if req.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case kind == "tags":
+		var tags []string
+		for tag := range r.tags[name] {
+			tags = append(tags, fmt.Sprintf("%q", tag))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"name":%q,"tags":[%s]}`, name, strings.Join(tags, ","))
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+// testArchive packages the test chart and returns the archive path.
+func testArchive(t *testing.T) string {
+	t.Helper()
+
+	chart, err := loader.Load(testChart)
+	if err != nil {
+		t.Fatalf("failed to load the test chart: %v", err)
+	}
+	archive, err := chartutil.Save(chart, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to package the test chart: %v", err)
+	}
+
+	return archive
+}
+
+func TestPush(t *testing.T) {
+	host := newOCIRegistry(t)
+
+	resp := Helm{}.push(&PushRequest{
+		chart:      testArchive(t),
+		remote:     "oci://" + host + "/charts",
+		plain_http: true,
+	})
+	if len(resp.err) > 0 {
+		t.Fatalf("push failed: %v", resp.err)
+	}
+	if resp.pushed_ref != host+"/charts/test:0.1.0" {
+		t.Errorf("unexpected pushed reference %q", resp.pushed_ref)
+	}
+	if !strings.HasPrefix(resp.digest, "sha256:") {
+		t.Errorf("expected a digest, got %q", resp.digest)
+	}
+
+	pull := Helm{}.pull(&PullRequest{
+		chart:      "oci://" + host + "/charts/test",
+		version:    "0.1.0",
+		plain_http: true,
+		dest_dir:   t.TempDir(),
+	})
+	if len(pull.err) > 0 {
+		t.Fatalf("pull failed: %v", pull.err)
+	}
+	if pull.digest != resp.digest {
+		t.Errorf("expected the pushed digest %q, got %q", resp.digest, pull.digest)
+	}
+	if filepath.Base(pull.path) != "test-0.1.0.tgz" {
+		t.Errorf("unexpected pulled chart %q", pull.path)
+	}
+}
+
+func TestPushFailures(t *testing.T) {
+	host := newOCIRegistry(t)
+
+	resp := Helm{}.push(&PushRequest{chart: testArchive(t), remote: "https://" + host + "/charts"})
+	if len(resp.err) == 0 {
+		t.Error("expected pushing to a non OCI remote to fail")
+	}
+
+	resp = Helm{}.push(&PushRequest{chart: filepath.Join(t.TempDir(), "missing.tgz"), remote: "oci://" + host + "/charts", plain_http: true})
+	if resp.err_kind != errKindChartNotFound {
+		t.Errorf("expected error kind %q, got %q: %v", errKindChartNotFound, resp.err_kind, resp.err)
+	}
+
+	resp = Helm{}.push(&PushRequest{chart: testChart, remote: "oci://" + host + "/charts", plain_http: true})
+	if len(resp.err) == 0 {
+		t.Error("expected pushing a chart directory to fail")
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+)
+
+// Policies restoring a release stuck in a pending status, after the process
+// running the operation was killed
+const (
+	// recoverMarkFailed marks the pending revision as failed, the next
+	// upgrade starts from it
+	recoverMarkFailed = "mark_failed"
+	// recoverRollback marks the pending revision as failed and rolls back to
+	// the last deployed revision
+	recoverRollback = "rollback"
+	// recoverDelete deletes the pending revision from the storage, the
+	// previous revision becomes the latest again
+	recoverDelete = "delete"
+)
+
+type recoverRelease struct {
+	ReleaseName string
+	Policy      string
+	// MinAge skips pending revisions younger than it, they may still be in
+	// progress in another process
+	MinAge time.Duration
+	// Wait and Timeout apply to the rollback policy
+	Wait    bool
+	Timeout int64
+}
+
+// recovered reports what recoverPending found and did.
+type recovered struct {
+	// Status of the latest revision before the recovery
+	Status release.Status
+	// Recovered is set when the pending revision was recovered
+	Recovered bool
+}
+
+func runRecover(ctx context.Context, logger *log.Logger, settings *helmSettings, recoverRelease recoverRelease) (*release.Release, recovered, error) {
+	actionConfig, err := initActionConfig(settings, logger)
+	if err != nil {
+		return nil, recovered{}, fmt.Errorf("failed to init action config: %w", err)
+	}
+	actionConfig.KubeClient = withContextKubeClient(ctx, actionConfig.KubeClient)
+
+	result, err := recoverPending(ctx, logger, actionConfig, recoverRelease)
+	if err != nil {
+		return nil, result, err
+	}
+
+	release, err := actionConfig.Releases.Last(recoverRelease.ReleaseName)
+	if err != nil {
+		// The delete policy removes a pending install entirely
+		if result.Recovered && recoverRelease.Policy == recoverDelete {
+			return nil, result, nil
+		}
+
+		return nil, result, fmt.Errorf("failed to get release after recovery: %w", err)
+	}
+
+	return release, result, nil
+}
+
+// recoverPending restores the release to a state it can be upgraded from when
+// its latest revision is pending, according to the policy.
+func recoverPending(ctx context.Context, logger *log.Logger, actionConfig *action.Configuration, recoverRelease recoverRelease) (recovered, error) {
+	last, err := actionConfig.Releases.Last(recoverRelease.ReleaseName)
+	if err != nil {
+		return recovered{}, fmt.Errorf("failed to get the latest revision: %w", err)
+	}
+
+	result := recovered{Status: last.Info.Status}
+	if !last.Info.Status.IsPending() {
+		return result, nil
+	}
+
+	if age := time.Since(last.Info.LastDeployed.Time); age < recoverRelease.MinAge {
+		logger.Printf("release %q revision %d is %s since %s, not recovering it yet", last.Name, last.Version, last.Info.Status, age.Round(time.Second))
+
+		return result, nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return result, fmt.Errorf("recover cancelled: %w", err)
+	}
+
+	description := fmt.Sprintf("Recovered from %s", last.Info.Status)
+
+	switch recoverRelease.Policy {
+	case "", recoverMarkFailed:
+		last.SetStatus(release.StatusFailed, description)
+		if err := actionConfig.Releases.Update(last); err != nil {
+			return result, fmt.Errorf("failed to mark revision %d as failed: %w", last.Version, err)
+		}
+	case recoverRollback:
+		target, err := lastDeployed(actionConfig, last)
+		if err != nil {
+			return result, err
+		}
+
+		last.SetStatus(release.StatusFailed, description)
+		if err := actionConfig.Releases.Update(last); err != nil {
+			return result, fmt.Errorf("failed to mark revision %d as failed: %w", last.Version, err)
+		}
+
+		rollbackClient := action.NewRollback(actionConfig)
+		rollbackClient.Version = target.Version
+		rollbackClient.Wait = recoverRelease.Wait
+		rollbackClient.Timeout = time.Duration(recoverRelease.Timeout) * time.Second
+		if err := rollbackClient.Run(last.Name); err != nil {
+			return result, fmt.Errorf("failed to roll back to revision %d: %w", target.Version, err)
+		}
+	case recoverDelete:
+		if _, err := actionConfig.Releases.Delete(last.Name, last.Version); err != nil {
+			return result, fmt.Errorf("failed to delete revision %d: %w", last.Version, err)
+		}
+	default:
+		return result, fmt.Errorf("unknown recover policy %q", recoverRelease.Policy)
+	}
+
+	logger.Printf("release %q revision %d recovered from %s with policy %s", last.Name, last.Version, result.Status, cmp.Or(recoverRelease.Policy, recoverMarkFailed))
+	result.Recovered = true
+
+	return result, nil
+}
+
+// lastDeployed returns the latest revision before pending that was deployed.
+func lastDeployed(actionConfig *action.Configuration, pending *release.Release) (*release.Release, error) {
+	history, err := actionConfig.Releases.History(pending.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the release history: %w", err)
+	}
+
+	releaseutil.Reverse(history, releaseutil.SortByRevision)
+	for _, rel := range history {
+		if rel.Version < pending.Version && (rel.Info.Status == release.StatusDeployed || rel.Info.Status == release.StatusSuperseded) {
+			return rel, nil
+		}
+	}
+
+	return nil, fmt.Errorf("release %q has no deployed revision before %d to roll back to", pending.Name, pending.Version)
+}
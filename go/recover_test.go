@@ -0,0 +1,198 @@
+package main
+
+import (
+	"log"
+	"testing"
+	"time"
+
+	"helm.sh/helm/v3/pkg/release"
+	helmtime "helm.sh/helm/v3/pkg/time"
+)
+
+// pendingRevision stores a revision stuck in status on top of the latest
+// one, like a process killed during the operation would leave it.
+func pendingRevision(t *testing.T, ns, name string, status release.Status, age time.Duration) {
+	t.Helper()
+
+	actionConfig, err := initActionConfig(initSettings(testEnv(fakeKubePrinting), ns), log.Default())
+	if err != nil {
+		t.Fatalf("failed to init action config: %v", err)
+	}
+
+	last, err := actionConfig.Releases.Last(name)
+	if err != nil {
+		t.Fatalf("failed to get the latest revision: %v", err)
+	}
+
+	pending := *last
+	info := *last.Info
+	pending.Info = &info
+	pending.Version++
+	pending.Info.Status = status
+	pending.Info.LastDeployed = helmtime.Time{Time: time.Now().Add(-age)}
+
+	if err := actionConfig.Releases.Create(&pending); err != nil {
+		t.Fatalf("failed to store the pending revision: %v", err)
+	}
+}
+
+func recoverTestRelease(t *testing.T, ns, name, policy string) RecoverResponse {
+	t.Helper()
+
+	resp := Helm{}.recover_release(&RecoverRequest{
+		release_name: name,
+		ns:           ns,
+		policy:       policy,
+		env:          testEnv(fakeKubePrinting),
+	})
+	if len(resp.err) > 0 {
+		t.Fatalf("recover failed: %v", resp.err)
+	}
+
+	return resp
+}
+
+func TestRecoverMarkFailed(t *testing.T) {
+	installTestRelease(t, "recover-failed", "podinfo")
+	pendingRevision(t, "recover-failed", "podinfo", release.StatusPendingUpgrade, time.Hour)
+
+	resp := recoverTestRelease(t, "recover-failed", "podinfo", recoverMarkFailed)
+	if !resp.recovered || resp.status != release.StatusPendingUpgrade.String() {
+		t.Errorf("expected the pending upgrade to be recovered, got %t from %q", resp.recovered, resp.status)
+	}
+	if len(resp.release) != 1 || resp.release[0].revision != 2 || resp.release[0].status != release.StatusFailed.String() {
+		t.Errorf("expected revision 2 to be failed, got %+v", resp.release)
+	}
+
+	if upgrade := upgradeTestRelease(t, "recover-failed", "podinfo"); upgrade.revision != 3 {
+		t.Errorf("expected the upgrade to revision 3, got %d", upgrade.revision)
+	}
+}
+
+func TestRecoverRollback(t *testing.T) {
+	installTestRelease(t, "recover-rollback", "podinfo")
+	upgradeTestRelease(t, "recover-rollback", "podinfo")
+	pendingRevision(t, "recover-rollback", "podinfo", release.StatusPendingUpgrade, time.Hour)
+
+	resp := recoverTestRelease(t, "recover-rollback", "podinfo", recoverRollback)
+	if !resp.recovered {
+		t.Error("expected the pending upgrade to be recovered")
+	}
+	if len(resp.release) != 1 || resp.release[0].revision != 4 || resp.release[0].status != release.StatusDeployed.String() {
+		t.Errorf("expected revision 4 to be deployed, got %+v", resp.release)
+	}
+
+	installTestRelease(t, "recover-rollback", "installing")
+	Helm{}.uninstall(&UninstallRequest{release_name: "installing", ns: "recover-rollback", keep_history: true, env: testEnv(fakeKubePrinting)})
+	pendingRevision(t, "recover-rollback", "installing", release.StatusPendingInstall, time.Hour)
+
+	failed := Helm{}.recover_release(&RecoverRequest{release_name: "installing", ns: "recover-rollback", policy: recoverRollback, env: testEnv(fakeKubePrinting)})
+	if len(failed.err) == 0 || failed.recovered {
+		t.Error("expected the rollback without a deployed revision to fail")
+	}
+}
+
+func TestRecoverCancelled(t *testing.T) {
+	id := testOperationID(t)
+	installTestRelease(t, "recover-cancelled", "podinfo")
+	upgradeTestRelease(t, "recover-cancelled", "podinfo")
+	pendingRevision(t, "recover-cancelled", "podinfo", release.StatusPendingUpgrade, time.Hour)
+
+	recovered := make(chan RecoverResponse)
+	go func() {
+		recovered <- Helm{}.recover_release(&RecoverRequest{
+			release_name: "podinfo",
+			ns:           "recover-cancelled",
+			policy:       recoverRollback,
+			wait:         true,
+			timeout:      []int64{60},
+			id:           id,
+			env:          testEnv(fakeKubeWaiting),
+		})
+	}()
+
+	waitForFakeWait(t)
+	Helm{}.cancel(&CancelRequest{id: id})
+
+	resp := <-recovered
+	if !resp.cancelled || resp.err_kind != errKindCancelled {
+		t.Errorf("expected the recover to be cancelled, got %q: %v", resp.err_kind, resp.err)
+	}
+}
+
+func TestRecoverDelete(t *testing.T) {
+	installTestRelease(t, "recover-delete", "podinfo")
+	pendingRevision(t, "recover-delete", "podinfo", release.StatusPendingRollback, time.Hour)
+
+	resp := recoverTestRelease(t, "recover-delete", "podinfo", recoverDelete)
+	if !resp.recovered || resp.status != release.StatusPendingRollback.String() {
+		t.Errorf("expected the pending rollback to be recovered, got %t from %q", resp.recovered, resp.status)
+	}
+	if len(resp.release) != 1 || resp.release[0].revision != 1 || resp.release[0].status != release.StatusDeployed.String() {
+		t.Errorf("expected revision 1 to be the latest, got %+v", resp.release)
+	}
+}
+
+func TestRecoverSkipped(t *testing.T) {
+	installTestRelease(t, "recover-skipped", "podinfo")
+
+	resp := recoverTestRelease(t, "recover-skipped", "podinfo", recoverMarkFailed)
+	if resp.recovered || resp.status != release.StatusDeployed.String() {
+		t.Errorf("expected a deployed release not to be recovered, got %t from %q", resp.recovered, resp.status)
+	}
+
+	pendingRevision(t, "recover-skipped", "podinfo", release.StatusPendingUpgrade, time.Minute)
+
+	resp = Helm{}.recover_release(&RecoverRequest{
+		release_name: "podinfo",
+		ns:           "recover-skipped",
+		min_age:      int64(time.Hour / time.Second),
+		env:          testEnv(fakeKubePrinting),
+	})
+	if len(resp.err) > 0 || resp.recovered {
+		t.Errorf("expected a recent pending revision not to be recovered: %v", resp.err)
+	}
+
+	resp = Helm{}.recover_release(&RecoverRequest{release_name: "podinfo", ns: "recover-skipped", policy: "unknown", env: testEnv(fakeKubePrinting)})
+	if len(resp.err) == 0 {
+		t.Error("expected an unknown policy to fail")
+	}
+
+	resp = Helm{}.recover_release(&RecoverRequest{release_name: "missing", ns: "recover-skipped", env: testEnv(fakeKubePrinting)})
+	if resp.err_kind != errKindReleaseNotFound {
+		t.Errorf("expected error kind %q, got %q: %v", errKindReleaseNotFound, resp.err_kind, resp.err)
+	}
+}
+
+func TestUpgradeRecoverPending(t *testing.T) {
+	installTestRelease(t, "upgrade-recover", "podinfo")
+	pendingRevision(t, "upgrade-recover", "podinfo", release.StatusPendingUpgrade, time.Hour)
+
+	req := UpgradeRequest{
+		release_name: "podinfo",
+		chart:        testChart,
+		ns:           "upgrade-recover",
+		env:          testEnv(fakeKubePrinting),
+	}
+
+	resp := Helm{}.upgrade(&req)
+	if resp.err_kind != errKindPending {
+		t.Fatalf("expected error kind %q, got %q: %v", errKindPending, resp.err_kind, resp.err)
+	}
+
+	req.recover_pending = true
+	req.recover_pending_after = int64(2 * time.Hour / time.Second)
+	resp = Helm{}.upgrade(&req)
+	if resp.err_kind != errKindPending {
+		t.Fatalf("expected the pending revision to be too recent, got %q: %v", resp.err_kind, resp.err)
+	}
+
+	req.recover_pending_after = int64(time.Minute / time.Second)
+	resp = Helm{}.upgrade(&req)
+	if len(resp.err) > 0 {
+		t.Fatalf("upgrade failed: %v", resp.err)
+	}
+	if resp.revision != 3 {
+		t.Errorf("expected the upgrade to revision 3, got %d", resp.revision)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"log"
 
 	"helm.sh/helm/v3/pkg/action"
-	"helm.sh/helm/v3/pkg/cli"
 )
 
 type login struct {
@@ -19,7 +18,7 @@ type login struct {
 	plainHTTP bool
 }
 
-func registryLogin(logger *log.Logger, settings *cli.EnvSettings, o login) error {
+func registryLogin(logger *log.Logger, settings *helmSettings, o login) error {
 	actionConfig, err := initActionConfig(settings, logger)
 	if err != nil {
 		return fmt.Errorf("failed to init action config: %w", err)
@@ -32,7 +31,10 @@ func registryLogin(logger *log.Logger, settings *cli.EnvSettings, o login) error
 		o.keyFile,
 		o.caFile,
 		o.insecure,
-		o.plainHTTP)
+		o.plainHTTP,
+		// The login action authenticates and stores the credentials itself
+		"",
+		"")
 	if err != nil {
 		return fmt.Errorf("failed to created registry client: %w", err)
 	}
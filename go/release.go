@@ -0,0 +1,51 @@
+package main
+
+import (
+	"time"
+
+	"helm.sh/helm/v3/pkg/release"
+	helmtime "helm.sh/helm/v3/pkg/time"
+)
+
+// releaseSummary copies the commonly used fields of a release, so the rust
+// side doesn't have to parse the release json.
+func releaseSummary(rel *release.Release) ReleaseSummary {
+	summary := ReleaseSummary{
+		name:      rel.Name,
+		namespace: rel.Namespace,
+		revision:  int64(rel.Version),
+	}
+
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		summary.chart_name = rel.Chart.Metadata.Name
+		summary.chart_version = rel.Chart.Metadata.Version
+		summary.app_version = rel.Chart.Metadata.AppVersion
+	}
+
+	if rel.Info != nil {
+		summary.status = rel.Info.Status.String()
+		summary.first_deployed = formatTime(rel.Info.FirstDeployed)
+		summary.last_deployed = formatTime(rel.Info.LastDeployed)
+		summary.description = rel.Info.Description
+		summary.notes = rel.Info.Notes
+	}
+
+	return summary
+}
+
+func releaseSummaries(releases []*release.Release) []ReleaseSummary {
+	summaries := make([]ReleaseSummary, 0, len(releases))
+	for _, rel := range releases {
+		summaries = append(summaries, releaseSummary(rel))
+	}
+
+	return summaries
+}
+
+func formatTime(t helmtime.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
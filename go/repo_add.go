@@ -14,7 +14,6 @@ import (
 	"github.com/gofrs/flock"
 	"sigs.k8s.io/yaml"
 
-	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/getter"
 	"helm.sh/helm/v3/pkg/repo"
 )
@@ -37,7 +36,7 @@ type repoAddOptions struct {
 	repoCache string
 }
 
-func (o *repoAddOptions) run(logger *log.Logger, settings *cli.EnvSettings) error {
+func (o *repoAddOptions) run(logger *log.Logger, settings *helmSettings) error {
 	// Ensure the file directory exists as it is required for file locking
 	err := os.MkdirAll(filepath.Dir(o.repoFile), os.ModePerm)
 	if err != nil && !os.IsExist(err) {
@@ -106,7 +105,7 @@ func (o *repoAddOptions) run(logger *log.Logger, settings *cli.EnvSettings) erro
 		return nil
 	}
 
-	r, err := repo.NewChartRepository(&c, getter.All(settings))
+	r, err := repo.NewChartRepository(&c, getter.All(settings.EnvSettings))
 	if err != nil {
 		return err
 	}
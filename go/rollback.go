@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+type rollback struct {
+	ReleaseName   string
+	Revision      int
+	Wait          bool
+	WaitForJobs   bool
+	Timeout       int64
+	Force         bool
+	Recreate      bool
+	CleanupOnFail bool
+	DisableHooks  bool
+	DryRun        bool
+}
+
+func runRollback(ctx context.Context, logger *log.Logger, settings *helmSettings, rollback rollback) (*release.Release, error) {
+	actionConfig, err := initActionConfig(settings, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init action config: %w", err)
+	}
+	actionConfig.KubeClient = withContextKubeClient(ctx, actionConfig.KubeClient)
+
+	rollbackClient := action.NewRollback(actionConfig)
+
+	rollbackClient.Version = rollback.Revision
+	rollbackClient.Wait = rollback.Wait
+	rollbackClient.WaitForJobs = rollback.WaitForJobs
+	rollbackClient.Timeout = time.Duration(rollback.Timeout) * time.Second
+	rollbackClient.Force = rollback.Force
+	rollbackClient.Recreate = rollback.Recreate
+	rollbackClient.CleanupOnFail = rollback.CleanupOnFail
+	rollbackClient.DisableHooks = rollback.DisableHooks
+	rollbackClient.DryRun = rollback.DryRun
+
+	// The rollback action does not take a context, only its waits stop once
+	// it is cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("rollback cancelled: %w", err)
+	}
+
+	if err := rollbackClient.Run(rollback.ReleaseName); err != nil {
+		return nil, fmt.Errorf("failed to run rollback action: %w", err)
+	}
+
+	// The rollback action only reports errors, return the release it produced
+	release, err := actionConfig.Releases.Last(rollback.ReleaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release after rollback: %w", err)
+	}
+
+	return release, nil
+}
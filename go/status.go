@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/kube"
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/kubernetes"
+)
+
+type status struct {
+	ReleaseName   string
+	Revision      int
+	ShowResources bool
+}
+
+type resourceReadiness struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Ready     bool
+	Reason    string
+}
+
+func runStatus(ctx context.Context, logger *log.Logger, settings *helmSettings, status status) (*release.Release, []resourceReadiness, error) {
+	actionConfig, err := initActionConfig(settings, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to init action config: %w", err)
+	}
+
+	statusClient := action.NewStatus(actionConfig)
+
+	statusClient.Version = status.Revision
+	statusClient.ShowResources = status.ShowResources
+
+	release, err := statusClient.Run(status.ReleaseName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to run status action: %w", err)
+	}
+
+	if !status.ShowResources {
+		return release, nil, nil
+	}
+
+	resources, err := actionConfig.KubeClient.Build(bytes.NewBufferString(release.Manifest), false)
+	if err != nil {
+		return release, nil, fmt.Errorf("failed to build release resources: %w", err)
+	}
+
+	clientSet, err := actionConfig.KubernetesClientSet()
+	if err != nil {
+		return release, nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	readiness := make([]resourceReadiness, 0, len(resources))
+	for _, info := range resources {
+		readiness = append(readiness, checkReadiness(ctx, clientSet, info))
+	}
+
+	return release, readiness, nil
+}
+
+// checkReadiness runs helm's ready checker, the same one used by --wait, on a
+// single resource. The checker only logs why a resource is not ready, so the
+// last logged line is kept as the reason.
+func checkReadiness(ctx context.Context, clientSet kubernetes.Interface, info *resource.Info) resourceReadiness {
+	readiness := resourceReadiness{
+		Name:      info.Name,
+		Namespace: info.Namespace,
+	}
+	if info.Mapping != nil {
+		readiness.Kind = info.Mapping.GroupVersionKind.Kind
+	}
+
+	checker := kube.NewReadyChecker(clientSet, func(format string, v ...interface{}) {
+		readiness.Reason = fmt.Sprintf(format, v...)
+	}, kube.PausedAsReady(true), kube.CheckJobs(true))
+
+	ready, err := checker.IsReady(ctx, info)
+	if err != nil {
+		readiness.Reason = err.Error()
+	}
+
+	readiness.Ready = ready && err == nil
+	if readiness.Ready {
+		readiness.Reason = ""
+	}
+
+	return readiness
+}
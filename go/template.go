@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+type template struct {
+	ReleaseName  string
+	ChartRef     string
+	ChartVersion string
+	KubeVersion  string
+	APIVersions  []string
+	IncludeCRDs  bool
+	IsUpgrade    bool
+	Values       map[string]interface{}
+
+	// DependencyUpdate updates the dependencies missing from charts/
+	// before rendering
+	DependencyUpdate bool
+	ChartSource      chartSource
+}
+
+// runTemplate renders the chart like `helm template`, without contacting the cluster.
+func runTemplate(ctx context.Context, logger *log.Logger, settings *helmSettings, template template) (*release.Release, error) {
+	// Client only installs replace the kube client, capabilities and storage
+	// with in-memory stand-ins, so the configuration is not initialized.
+	actionConfig := &action.Configuration{Log: debugLog(logger)}
+
+	installClient := action.NewInstall(actionConfig)
+
+	installClient.DryRun = true
+	installClient.DryRunOption = "true"
+	installClient.ClientOnly = true
+	installClient.Replace = true
+	installClient.ReleaseName = template.ReleaseName
+	installClient.Namespace = settings.Namespace()
+	installClient.Version = template.ChartVersion
+	installClient.IncludeCRDs = template.IncludeCRDs
+	installClient.IsUpgrade = template.IsUpgrade
+	installClient.DependencyUpdate = template.DependencyUpdate
+	installClient.APIVersions = chartutil.VersionSet(template.APIVersions)
+
+	if template.KubeVersion != "" {
+		kubeVersion, err := chartutil.ParseKubeVersion(template.KubeVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kube version %q: %w", template.KubeVersion, err)
+		}
+		installClient.KubeVersion = kubeVersion
+	}
+
+	template.ChartSource.apply(&installClient.ChartPathOptions)
+
+	registryClient, err := template.ChartSource.registryClient(settings, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to created registry client: %w", err)
+	}
+	installClient.SetRegistryClient(registryClient)
+
+	chartPath, err := installClient.ChartPathOptions.LocateChart(template.ChartRef, settings.EnvSettings)
+	if err != nil {
+		return nil, withKind(errKindChartNotFound, fmt.Errorf("failed to locate chart: %w", err))
+	}
+
+	chart, err := prepareChart(ctx, logger, settings, chartPath, template.DependencyUpdate, installClient.Keyring, registryClient)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := installClient.RunWithContext(ctx, chart, template.Values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render chart: %w", err)
+	}
+
+	return release, nil
+}
+
+// hookManifests joins the release hooks into a manifest stream, in the same
+// format `helm template` prints them.
+func hookManifests(rel *release.Release) string {
+	var hooks strings.Builder
+	for _, hook := range rel.Hooks {
+		fmt.Fprintf(&hooks, "---\n# Source: %s\n%s\n", hook.Path, hook.Manifest)
+	}
+
+	return hooks.String()
+}
@@ -1,12 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
 	"helm.sh/helm/v3/pkg/action"
-	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/release"
 )
 
@@ -22,7 +22,7 @@ type uninstall struct {
 	Description         string
 }
 
-func runUninstall(logger *log.Logger, settings *cli.EnvSettings, uninstall uninstall) (*release.UninstallReleaseResponse, error) {
+func runUninstall(ctx context.Context, logger *log.Logger, settings *helmSettings, uninstall uninstall) (*release.UninstallReleaseResponse, error) {
 	actionConfig, err := initActionConfig(settings, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init action config: %w", err)
@@ -39,6 +39,13 @@ func runUninstall(logger *log.Logger, settings *cli.EnvSettings, uninstall unins
 	uninstallClient.Description = uninstall.Description
 	uninstallClient.DeletionPropagation = uninstall.DeletionPropagation
 
+	// The uninstall action does not take a context, so cancellation only
+	// prevents it from starting
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("uninstall cancelled: %w", err)
+	}
+
+	eventsFrom(ctx).enter(phaseUninstalling)
 	result, err := uninstallClient.Run(uninstall.ReleaseName)
 	if err != nil {
 		return result, fmt.Errorf("failed to run uninstall action: %w", err)
@@ -2,16 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"helm.sh/helm/v3/pkg/action"
-	"helm.sh/helm/v3/pkg/chart/loader"
-	"helm.sh/helm/v3/pkg/cli"
-	"helm.sh/helm/v3/pkg/downloader"
-	"helm.sh/helm/v3/pkg/getter"
 	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/storage/driver"
 )
 
 type upgrade struct {
@@ -24,81 +23,145 @@ type upgrade struct {
 	ReuseValues  bool
 	ResetValues  bool
 	Values       map[string]interface{}
+
+	Install         bool
+	CreateNamespace bool
+
+	Atomic        bool
+	CleanupOnFail bool
+	WaitForJobs   bool
+	MaxHistory    int
+
+	// RecoverPending recovers a pending latest revision older than
+	// RecoverAfter with RecoverPolicy before upgrading
+	RecoverPending bool
+	RecoverPolicy  string
+	RecoverAfter   time.Duration
+
+	// DependencyUpdate updates the dependencies missing from charts/
+	// before upgrading
+	DependencyUpdate bool
+	ChartSource      chartSource
 }
 
-func runUpgrade(ctx context.Context, logger *log.Logger, settings *cli.EnvSettings, upgrade upgrade) (*release.Release, error) {
+func runUpgrade(ctx context.Context, logger *log.Logger, settings *helmSettings, upgrade upgrade) (*release.Release, recovery, error) {
 	actionConfig, err := initActionConfig(settings, logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to init action config: %w", err)
+		return nil, recovery{}, fmt.Errorf("failed to init action config: %w", err)
+	}
+
+	if upgrade.RecoverPending {
+		_, err := recoverPending(ctx, logger, actionConfig, recoverRelease{
+			ReleaseName: upgrade.ReleaseName,
+			Policy:      upgrade.RecoverPolicy,
+			MinAge:      upgrade.RecoverAfter,
+			Wait:        upgrade.Wait,
+			Timeout:     upgrade.Timeout,
+		})
+		if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+			return nil, recovery{}, fmt.Errorf("failed to recover pending release: %w", err)
+		}
 	}
 
+	if upgrade.Install {
+		status, err := latestStatus(actionConfig, upgrade.ReleaseName)
+		if err != nil {
+			return nil, recovery{}, err
+		}
+
+		if status == "" || status == release.StatusUninstalled {
+			logger.Printf("release %q does not exist, installing it now", upgrade.ReleaseName)
+
+			return runInstall(ctx, logger, settings, install{
+				ReleaseName:      upgrade.ReleaseName,
+				ChartRef:         upgrade.ChartRef,
+				ChartVersion:     upgrade.ChartVersion,
+				Wait:             upgrade.Wait,
+				Timeout:          upgrade.Timeout,
+				CreateNamespace:  upgrade.CreateNamespace,
+				DryRunOption:     upgrade.DryRunOption,
+				Values:           upgrade.Values,
+				Atomic:           upgrade.Atomic,
+				WaitForJobs:      upgrade.WaitForJobs,
+				DependencyUpdate: upgrade.DependencyUpdate,
+				ChartSource:      upgrade.ChartSource,
+				Replace:          status == release.StatusUninstalled,
+			})
+		}
+	}
+
+	events := eventsFrom(ctx)
+
 	upgradeClient := action.NewUpgrade(actionConfig)
 
 	upgradeClient.Namespace = settings.Namespace()
 	upgradeClient.DryRunOption = "none"
 	upgradeClient.Version = upgrade.ChartVersion
 	upgradeClient.Wait = upgrade.Wait
+	upgradeClient.WaitForJobs = upgrade.WaitForJobs
+	upgradeClient.Atomic = upgrade.Atomic
+	upgradeClient.CleanupOnFail = upgrade.CleanupOnFail
+	upgradeClient.MaxHistory = upgrade.MaxHistory
 	upgradeClient.ReuseValues = upgrade.ReuseValues
 	upgradeClient.ResetValues = upgrade.ResetValues
 	upgradeClient.Timeout = time.Duration(upgrade.Timeout) * time.Second
 	upgradeClient.DryRunOption = get(upgrade.DryRunOption)
+	upgradeClient.DependencyUpdate = upgrade.DependencyUpdate
 
-	registryClient, err := newRegistryClientTLS(
-		settings,
-		logger,
-		upgradeClient.CertFile,
-		upgradeClient.KeyFile,
-		upgradeClient.CaFile,
-		upgradeClient.InsecureSkipTLSverify,
-		upgradeClient.PlainHTTP)
+	upgrade.ChartSource.apply(&upgradeClient.ChartPathOptions)
+
+	registryClient, err := upgrade.ChartSource.registryClient(settings, logger)
 	if err != nil {
-		return nil, fmt.Errorf("missing registry client: %w", err)
+		return nil, recovery{}, fmt.Errorf("missing registry client: %w", err)
 	}
 	upgradeClient.SetRegistryClient(registryClient)
 
-	chartPath, err := upgradeClient.ChartPathOptions.LocateChart(upgrade.ChartRef, settings)
+	events.enter(phaseLocating)
+	chartPath, err := upgradeClient.ChartPathOptions.LocateChart(upgrade.ChartRef, settings.EnvSettings)
 	if err != nil {
-		return nil, err
+		return nil, recovery{}, withKind(errKindChartNotFound, err)
 	}
 
-	providers := getter.All(settings)
-
-	// Check chart dependencies to make sure all are present in /charts
-	chart, err := loader.Load(chartPath)
+	chart, err := prepareChart(ctx, logger, settings, chartPath, upgrade.DependencyUpdate, upgradeClient.Keyring, registryClient)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load chart: %w", err)
+		return nil, recovery{}, err
 	}
-	if req := chart.Metadata.Dependencies; req != nil {
-		if err := action.CheckDependencies(chart, req); err != nil {
-			err = fmt.Errorf("failed to check chart dependencies: %w", err)
-			if !upgradeClient.DependencyUpdate {
-				return nil, err
-			}
-
-			man := &downloader.Manager{
-				Out:              logger.Writer(),
-				ChartPath:        chartPath,
-				Keyring:          upgradeClient.ChartPathOptions.Keyring,
-				SkipUpdate:       false,
-				Getters:          providers,
-				RepositoryConfig: settings.RepositoryConfig,
-				RepositoryCache:  settings.RepositoryCache,
-				Debug:            settings.Debug,
-			}
-			if err := man.Update(); err != nil {
-				return nil, err
-			}
-			// Reload the chart with the updated Chart.lock file.
-			if chart, err = loader.Load(chartPath); err != nil {
-				return nil, fmt.Errorf("failed to reload chart after repo update: %w", err)
-			}
-		}
+
+	// Locating and loading the chart can take a while, don't start the release
+	// if the request was cancelled in the meantime
+	if err := ctx.Err(); err != nil {
+		return nil, recovery{}, fmt.Errorf("upgrade cancelled: %w", err)
 	}
 
+	events.enter(phaseRendering)
 	release, err := upgradeClient.RunWithContext(ctx, upgrade.ReleaseName, chart, upgrade.Values)
 	if err != nil {
-		return nil, fmt.Errorf("failed to run upgrade action: %w", err)
+		return nil, releaseRecovery(actionConfig, upgrade.ReleaseName, upgrade.Atomic, release), fmt.Errorf("failed to run upgrade action: %w", err)
 	}
 
-	return release, nil
+	return release, recovery{Revision: release.Version}, nil
+}
+
+// latestStatus returns the status of the latest revision of the release, or
+// an empty status when it has no revision. Unlike `helm upgrade --install`,
+// which only installs when the release is not found, an uninstalled release
+// whose history was kept is installed again, replacing its name.
+func latestStatus(actionConfig *action.Configuration, name string) (release.Status, error) {
+	historyClient := action.NewHistory(actionConfig)
+	historyClient.Max = 1
+
+	versions, err := historyClient.Run(name)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get release history: %w", err)
+	}
+	if len(versions) == 0 {
+		return "", nil
+	}
+
+	releaseutil.SortByRevision(versions)
+
+	return versions[len(versions)-1].Info.Status, nil
 }
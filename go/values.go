@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/strvals"
+)
+
+// mergeValues layers the request values the same way values.Options.MergeValues
+// does, with the json blob from the rust side merged on top of the values files
+// and below the --set style overrides:
+//
+//	values files < json blob < --set-json < --set < --set-string < --set-file < --set-literal
+func mergeValues(settings *helmSettings, opts values.Options, blob []byte) (map[string]interface{}, error) {
+	providers := getter.All(settings.EnvSettings)
+
+	files := values.Options{ValueFiles: opts.ValueFiles}
+	base, err := files.MergeValues(providers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read values files: %w", err)
+	}
+
+	if len(blob) > 0 {
+		current := map[string]interface{}{}
+		if err := json.Unmarshal(blob, &current); err != nil {
+			return nil, fmt.Errorf("failed to parse values: %w", err)
+		}
+		base = mergeMaps(base, current)
+	}
+
+	for _, value := range opts.JSONValues {
+		if err := strvals.ParseJSON(value, base); err != nil {
+			return nil, fmt.Errorf("failed parsing --set-json data %s", value)
+		}
+	}
+
+	for _, value := range opts.Values {
+		if err := strvals.ParseInto(value, base); err != nil {
+			return nil, fmt.Errorf("failed parsing --set data: %w", err)
+		}
+	}
+
+	for _, value := range opts.StringValues {
+		if err := strvals.ParseIntoString(value, base); err != nil {
+			return nil, fmt.Errorf("failed parsing --set-string data: %w", err)
+		}
+	}
+
+	for _, value := range opts.FileValues {
+		reader := func(rs []rune) (interface{}, error) {
+			bytes, err := readValuesFile(string(rs), providers)
+			if err != nil {
+				return nil, err
+			}
+			return string(bytes), err
+		}
+		if err := strvals.ParseIntoFile(value, base, reader); err != nil {
+			return nil, fmt.Errorf("failed parsing --set-file data: %w", err)
+		}
+	}
+
+	for _, value := range opts.LiteralValues {
+		if err := strvals.ParseLiteralInto(value, base); err != nil {
+			return nil, fmt.Errorf("failed parsing --set-literal data: %w", err)
+		}
+	}
+
+	return base, nil
+}
+
+// mergeMaps is a copy of the unexported helper in pkg/cli/values.
+func mergeMaps(a, b map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(a))
+	for k, v := range a {
+		out[k] = v
+	}
+	for k, v := range b {
+		if v, ok := v.(map[string]interface{}); ok {
+			if bv, ok := out[k]; ok {
+				if bv, ok := bv.(map[string]interface{}); ok {
+					out[k] = mergeMaps(bv, v)
+					continue
+				}
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// readValuesFile is a copy of the unexported readFile in pkg/cli/values, it
+// loads a file from stdin, the local directory, or a remote file with a url.
+func readValuesFile(filePath string, p getter.Providers) ([]byte, error) {
+	if strings.TrimSpace(filePath) == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	u, err := url.Parse(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := p.ByScheme(u.Scheme)
+	if err != nil {
+		return os.ReadFile(filePath)
+	}
+	data, err := g.Get(filePath, getter.WithURL(filePath))
+	if err != nil {
+		return nil, err
+	}
+	return data.Bytes(), err
+}
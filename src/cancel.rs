@@ -0,0 +1,38 @@
+use std::sync::atomic::{AtomicU64, Ordering};
+
+use crate::{CancelRequest, HelmCall as _, HelmCallImpl};
+
+// Ids start at 1, 0 tells the go side the request can't be cancelled
+static NEXT_ID: AtomicU64 = AtomicU64::new(1);
+
+// CancelGuard cancels the go side operation when the future awaiting it is
+// dropped before the response arrives.
+pub(crate) struct CancelGuard {
+    id: u64,
+    armed: bool,
+}
+
+impl CancelGuard {
+    pub(crate) fn new() -> Self {
+        CancelGuard {
+            id: NEXT_ID.fetch_add(1, Ordering::Relaxed),
+            armed: true,
+        }
+    }
+
+    pub(crate) fn id(&self) -> u64 {
+        self.id
+    }
+
+    pub(crate) fn disarm(mut self) {
+        self.armed = false;
+    }
+}
+
+impl Drop for CancelGuard {
+    fn drop(&mut self) {
+        if self.armed {
+            HelmCallImpl::cancel(&CancelRequest { id: self.id });
+        }
+    }
+}
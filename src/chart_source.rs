@@ -0,0 +1,21 @@
+// ChartSource holds the options used to fetch the chart from a repository or
+// an OCI registry, like the flags shared by helm install and upgrade
+#[derive(Clone, Debug, Default)]
+pub struct ChartSource {
+    pub cert_file: String,
+    pub key_file: String,
+    pub ca_file: String,
+    pub insecure: bool,
+    // PlainHTTP talks to the OCI registry over http
+    pub plain_http: bool,
+    pub username: String,
+    pub password: String,
+    // PassCredentialsAll sends the credentials to every domain, not only the
+    // repository one
+    pub pass_credentials_all: bool,
+    // RepoURL looks the chart up in this repository instead of the local ones
+    pub repo_url: String,
+    // Keyring defaults to the GnuPG public keyring of the user
+    pub keyring: String,
+    pub verify: bool,
+}
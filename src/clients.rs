@@ -0,0 +1,62 @@
+use crate::{
+    HelmCall as _, HelmCallImpl, InvalidateClientsRequest, WarmClientsRequest, env::Env,
+    error::call_error,
+};
+
+// WarmClients builds the kube clients of a cluster ahead of the first call,
+// the following calls with the same env and namespace reuse them. The env
+// needs a client_cache_ttl, the cache is disabled without it
+#[derive(Clone, Debug, Default)]
+pub struct WarmClients {
+    pub ns: String,
+    pub env: Env,
+}
+
+impl From<WarmClients> for WarmClientsRequest {
+    fn from(req: WarmClients) -> Self {
+        WarmClientsRequest {
+            ns: req.ns,
+            env: req.env.into(),
+        }
+    }
+}
+
+call_error! {
+    pub enum WarmClientsError("warm clients") {}
+}
+
+pub async fn warm_clients(req: WarmClients) -> Result<(), WarmClientsError> {
+    let res = HelmCallImpl::warm_clients(req.into()).await;
+    if let Some(err) = res.0.err.first() {
+        return Err(WarmClientsError::new(
+            res.0.err_kind.as_str().into(),
+            err.clone(),
+            res.0.logs,
+        ));
+    }
+
+    Ok(())
+}
+
+// InvalidateClients forgets the cached kube clients of a cluster, or of all
+// of them, after the credentials or the api resources changed
+#[derive(Clone, Debug, Default)]
+pub struct InvalidateClients {
+    pub ns: String,
+    pub env: Env,
+    pub all: bool,
+}
+
+impl From<InvalidateClients> for InvalidateClientsRequest {
+    fn from(req: InvalidateClients) -> Self {
+        InvalidateClientsRequest {
+            ns: req.ns,
+            env: req.env.into(),
+            all: req.all,
+        }
+    }
+}
+
+pub fn invalidate_clients(req: InvalidateClients) {
+    HelmCallImpl::invalidate_clients(&req.into());
+}
@@ -13,6 +13,48 @@ pub struct Env {
     // KubeInsecureSkipTLSVerify indicates if server's certificate will not be checked for validity.
     // This makes the HTTPS connections insecure
     pub kube_insecure_skip_tls_verify: bool,
+    // KubeAPIServer overrides the address of the kubernetes api server
+    pub kube_api_server: Option<String>,
+    // KubeAsUser is the username to impersonate for the operation
+    pub kube_as_user: Option<String>,
+    // KubeAsGroups are the groups to impersonate for the operation
+    pub kube_as_groups: Vec<String>,
+    // KubeTLSServerName is the server name used to validate the api server
+    // certificate, it defaults to the hostname of the api server
+    pub kube_tls_server_name: Option<String>,
+    // BurstLimit is the client-side throttling limit, it defaults to HELM_BURST_LIMIT
+    pub burst_limit: Option<i64>,
+    // QPS is the queries per second of the client, it defaults to HELM_QPS
+    pub qps: Option<f32>,
+    // KubeConfigData is an inline kubeconfig, used instead of the kubeconfig files
+    pub kube_config_data: Option<Vec<u8>>,
+    // KubeCaData is the inline PEM certificate authority of the api server
+    pub kube_ca_data: Option<Vec<u8>>,
+    // KubeClientCertData and KubeClientKeyData are the inline PEM client
+    // certificate and key
+    pub kube_client_cert_data: Option<Vec<u8>>,
+    pub kube_client_key_data: Option<Vec<u8>>,
+    // KubeInCluster uses the service account of the pod, the inline options
+    // override it
+    pub kube_in_cluster: bool,
+    // Debug enables helm debug output, it lowers the log level to debug
+    pub debug: bool,
+    // LogLevel of the lines returned with the errors: debug, info, warning or error
+    pub log_level: Option<String>,
+    // Driver is the release storage driver: secret, configmap, sql or memory,
+    // it defaults to HELM_DRIVER. The memory driver persists across calls
+    pub driver: Option<String>,
+    // DriverConnection is the sql driver connection string, it defaults to
+    // HELM_DRIVER_SQL_CONNECTION_STRING
+    pub driver_connection: Option<String>,
+    // FakeKubeClient runs the calls without a cluster, for tests: printing
+    // accepts every change, failing rejects them. It defaults to the memory driver
+    pub fake_kube_client: Option<String>,
+    // ClientCacheTTL is how long in seconds the kube clients are reused across
+    // calls, unset or 0 disables the cache. Only the rest client, discovery
+    // and rest mapper are cached, a changed kubeconfig file is not read again
+    // before they expire or are invalidated
+    pub client_cache_ttl: Option<i64>,
 }
 
 impl From<Env> for HelmEnv {
@@ -23,6 +65,23 @@ impl From<Env> for HelmEnv {
             kube_token: value.kube_token.into_iter().collect(),
             kube_ca_file: value.kube_ca_file.into_iter().collect(),
             kube_insecure_skip_tls_verify: value.kube_insecure_skip_tls_verify,
+            kube_api_server: value.kube_api_server.into_iter().collect(),
+            kube_as_user: value.kube_as_user.into_iter().collect(),
+            kube_as_groups: value.kube_as_groups,
+            kube_tls_server_name: value.kube_tls_server_name.into_iter().collect(),
+            burst_limit: value.burst_limit.into_iter().collect(),
+            qps: value.qps.into_iter().collect(),
+            kube_config_data: value.kube_config_data.unwrap_or_default(),
+            kube_ca_data: value.kube_ca_data.unwrap_or_default(),
+            kube_client_cert_data: value.kube_client_cert_data.unwrap_or_default(),
+            kube_client_key_data: value.kube_client_key_data.unwrap_or_default(),
+            kube_in_cluster: value.kube_in_cluster,
+            debug: value.debug,
+            log_level: value.log_level.into_iter().collect(),
+            driver: value.driver.into_iter().collect(),
+            driver_connection: value.driver_connection.into_iter().collect(),
+            fake_kube_client: value.fake_kube_client.into_iter().collect(),
+            client_cache_ttl: value.client_cache_ttl.into_iter().collect(),
         }
     }
 }
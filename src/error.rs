@@ -0,0 +1,172 @@
+// ErrorKind classifies the errors returned by the go side, so callers don't
+// have to match on the error messages.
+#[derive(Clone, Copy, Debug, Default, PartialEq, Eq, Hash)]
+pub enum ErrorKind {
+    // The cause of the error is not known
+    #[default]
+    Unknown,
+    ReleaseNotFound,
+    ReleaseExists,
+    // Another install, upgrade or rollback is in progress for the release
+    Pending,
+    // Locked is another call of this process holding the release lock
+    Locked,
+    Timeout,
+    Cancelled,
+    // Kube errors are kubernetes API status errors
+    KubeNotFound,
+    KubeConflict,
+    KubeForbidden,
+    KubeUnauthorized,
+    KubeInvalid,
+    Kube,
+    // Auth is a chart repository or registry rejecting the credentials
+    Auth,
+    ChartNotFound,
+    // ChartInvalid covers charts failing to load, missing dependencies and
+    // values not matching the chart schema
+    ChartInvalid,
+}
+
+impl From<&str> for ErrorKind {
+    fn from(kind: &str) -> Self {
+        match kind {
+            "release_not_found" => ErrorKind::ReleaseNotFound,
+            "release_exists" => ErrorKind::ReleaseExists,
+            "pending" => ErrorKind::Pending,
+            "locked" => ErrorKind::Locked,
+            "timeout" => ErrorKind::Timeout,
+            "cancelled" => ErrorKind::Cancelled,
+            "kube_not_found" => ErrorKind::KubeNotFound,
+            "kube_conflict" => ErrorKind::KubeConflict,
+            "kube_forbidden" => ErrorKind::KubeForbidden,
+            "kube_unauthorized" => ErrorKind::KubeUnauthorized,
+            "kube_invalid" => ErrorKind::KubeInvalid,
+            "kube" => ErrorKind::Kube,
+            "auth" => ErrorKind::Auth,
+            "chart_not_found" => ErrorKind::ChartNotFound,
+            "chart_invalid" => ErrorKind::ChartInvalid,
+            _ => ErrorKind::Unknown,
+        }
+    }
+}
+
+// call_error declares the error of a call with one variant per ErrorKind, so
+// callers can match on the kind, e.g. `Err(InstallError::Timeout { .. })`.
+// Every kind variant carries the go error, the captured logs and the call
+// specific fields. Extra variants must have a kind field, kind() returns it.
+macro_rules! call_error {
+    (
+        pub enum $name:ident($op:literal) {
+            $($field:ident: $ty:ty,)*
+        }
+        $(
+            $(#[$extra_attr:meta])*
+            $extra:ident { $($extra_field:ident: $extra_ty:ty,)* }
+        )*
+    ) => {
+        #[derive(thiserror::Error, Debug)]
+        pub enum $name {
+            // The cause of the error is not known
+            #[error("{} error: {err}", $op)]
+            Unknown { err: String, logs: Vec<String>, $($field: $ty,)* },
+            #[error("{} error: {err}", $op)]
+            ReleaseNotFound { err: String, logs: Vec<String>, $($field: $ty,)* },
+            #[error("{} error: {err}", $op)]
+            ReleaseExists { err: String, logs: Vec<String>, $($field: $ty,)* },
+            // Another install, upgrade or rollback is in progress for the release
+            #[error("{} error: {err}", $op)]
+            Pending { err: String, logs: Vec<String>, $($field: $ty,)* },
+            // Another call of this process holds the release lock
+            #[error("{} error: {err}", $op)]
+            Locked { err: String, logs: Vec<String>, $($field: $ty,)* },
+            #[error("{} error: {err}", $op)]
+            Timeout { err: String, logs: Vec<String>, $($field: $ty,)* },
+            #[error("{} cancelled: {err}", $op)]
+            Cancelled { err: String, logs: Vec<String>, $($field: $ty,)* },
+            // Kube variants are kubernetes API status errors
+            #[error("{} error: {err}", $op)]
+            KubeNotFound { err: String, logs: Vec<String>, $($field: $ty,)* },
+            #[error("{} error: {err}", $op)]
+            KubeConflict { err: String, logs: Vec<String>, $($field: $ty,)* },
+            #[error("{} error: {err}", $op)]
+            KubeForbidden { err: String, logs: Vec<String>, $($field: $ty,)* },
+            #[error("{} error: {err}", $op)]
+            KubeUnauthorized { err: String, logs: Vec<String>, $($field: $ty,)* },
+            #[error("{} error: {err}", $op)]
+            KubeInvalid { err: String, logs: Vec<String>, $($field: $ty,)* },
+            #[error("{} error: {err}", $op)]
+            Kube { err: String, logs: Vec<String>, $($field: $ty,)* },
+            // A chart repository or registry rejected the credentials
+            #[error("{} error: {err}", $op)]
+            Auth { err: String, logs: Vec<String>, $($field: $ty,)* },
+            #[error("{} error: {err}", $op)]
+            ChartNotFound { err: String, logs: Vec<String>, $($field: $ty,)* },
+            // The chart failed to load, misses dependencies or its values
+            // don't match the schema
+            #[error("{} error: {err}", $op)]
+            ChartInvalid { err: String, logs: Vec<String>, $($field: $ty,)* },
+            $(
+                $(#[$extra_attr])*
+                $extra { $($extra_field: $extra_ty,)* },
+            )*
+        }
+
+        impl $name {
+            pub fn kind(&self) -> $crate::error::ErrorKind {
+                use $crate::error::ErrorKind;
+
+                match self {
+                    $name::Unknown { .. } => ErrorKind::Unknown,
+                    $name::ReleaseNotFound { .. } => ErrorKind::ReleaseNotFound,
+                    $name::ReleaseExists { .. } => ErrorKind::ReleaseExists,
+                    $name::Pending { .. } => ErrorKind::Pending,
+                    $name::Locked { .. } => ErrorKind::Locked,
+                    $name::Timeout { .. } => ErrorKind::Timeout,
+                    $name::Cancelled { .. } => ErrorKind::Cancelled,
+                    $name::KubeNotFound { .. } => ErrorKind::KubeNotFound,
+                    $name::KubeConflict { .. } => ErrorKind::KubeConflict,
+                    $name::KubeForbidden { .. } => ErrorKind::KubeForbidden,
+                    $name::KubeUnauthorized { .. } => ErrorKind::KubeUnauthorized,
+                    $name::KubeInvalid { .. } => ErrorKind::KubeInvalid,
+                    $name::Kube { .. } => ErrorKind::Kube,
+                    $name::Auth { .. } => ErrorKind::Auth,
+                    $name::ChartNotFound { .. } => ErrorKind::ChartNotFound,
+                    $name::ChartInvalid { .. } => ErrorKind::ChartInvalid,
+                    $($name::$extra { kind, .. } => *kind,)*
+                }
+            }
+
+            // new returns the variant of the kind reported by the go side
+            pub(crate) fn new(
+                kind: $crate::error::ErrorKind,
+                err: String,
+                logs: Vec<String>,
+                $($field: $ty,)*
+            ) -> Self {
+                use $crate::error::ErrorKind;
+
+                match kind {
+                    ErrorKind::Unknown => $name::Unknown { err, logs, $($field,)* },
+                    ErrorKind::ReleaseNotFound => $name::ReleaseNotFound { err, logs, $($field,)* },
+                    ErrorKind::ReleaseExists => $name::ReleaseExists { err, logs, $($field,)* },
+                    ErrorKind::Pending => $name::Pending { err, logs, $($field,)* },
+                    ErrorKind::Locked => $name::Locked { err, logs, $($field,)* },
+                    ErrorKind::Timeout => $name::Timeout { err, logs, $($field,)* },
+                    ErrorKind::Cancelled => $name::Cancelled { err, logs, $($field,)* },
+                    ErrorKind::KubeNotFound => $name::KubeNotFound { err, logs, $($field,)* },
+                    ErrorKind::KubeConflict => $name::KubeConflict { err, logs, $($field,)* },
+                    ErrorKind::KubeForbidden => $name::KubeForbidden { err, logs, $($field,)* },
+                    ErrorKind::KubeUnauthorized => $name::KubeUnauthorized { err, logs, $($field,)* },
+                    ErrorKind::KubeInvalid => $name::KubeInvalid { err, logs, $($field,)* },
+                    ErrorKind::Kube => $name::Kube { err, logs, $($field,)* },
+                    ErrorKind::Auth => $name::Auth { err, logs, $($field,)* },
+                    ErrorKind::ChartNotFound => $name::ChartNotFound { err, logs, $($field,)* },
+                    ErrorKind::ChartInvalid => $name::ChartInvalid { err, logs, $($field,)* },
+                }
+            }
+        }
+    };
+}
+
+pub(crate) use call_error;
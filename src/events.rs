@@ -0,0 +1,103 @@
+use std::{
+    future::{Future, poll_fn},
+    pin::pin,
+    sync::mpsc::Sender,
+    task::Poll,
+};
+
+use crate::{EventsRequest, EventsResponse, HelmCall as _, HelmCallImpl};
+
+#[derive(Clone, Copy, Debug, PartialEq, Eq)]
+pub enum EventKind {
+    // The operation entered a new phase
+    Phase,
+    // A helm log line
+    Log,
+    // Readiness of a resource while waiting
+    Wait,
+}
+
+#[derive(Clone, Debug)]
+pub struct Event {
+    // Id of the request the event belongs to
+    pub id: u64,
+    pub kind: EventKind,
+    // Phase the operation was in: locating, loading, dependencies, rendering,
+    // applying, hooks, waiting or uninstalling
+    pub phase: String,
+    pub message: String,
+    // Resource is kind/namespace/name for wait events
+    pub resource: String,
+    // Time in milliseconds since the unix epoch
+    pub time: i64,
+}
+
+// EventSender receives the events of install, upgrade and uninstall while
+// they run.
+pub type EventSender = Sender<Event>;
+
+fn send(id: u64, events: &EventSender, res: EventsResponse) {
+    for e in res.events {
+        // The receiver going away doesn't stop the operation
+        let _ = events.send(Event {
+            id,
+            kind: match e.kind.as_str() {
+                "phase" => EventKind::Phase,
+                "wait" => EventKind::Wait,
+                _ => EventKind::Log,
+            },
+            phase: e.phase,
+            message: e.message,
+            resource: e.resource,
+            time: e.time,
+        });
+    }
+}
+
+// with_events drives op while polling the events of the request with the given
+// id, and returns once op completed and all its events were sent.
+pub(crate) async fn with_events<F: Future>(
+    id: u64,
+    events: Option<EventSender>,
+    op: F,
+) -> F::Output {
+    let Some(events) = events else {
+        return op.await;
+    };
+
+    // Registered before the first poll, next_events reports an unknown stream
+    // as done
+    HelmCallImpl::open_events(&EventsRequest { id });
+
+    let mut op = pin!(op);
+    let mut next = pin!(HelmCallImpl::next_events(EventsRequest { id }));
+    let mut done = false;
+
+    let output = poll_fn(|cx| {
+        while !done {
+            let Poll::Ready(res) = next.as_mut().poll(cx) else {
+                break;
+            };
+            done = res.0.done;
+            send(id, &events, res.0);
+            if !done {
+                next.set(HelmCallImpl::next_events(EventsRequest { id }));
+            }
+        }
+
+        op.as_mut().poll(cx)
+    })
+    .await;
+
+    // The go side closes the stream before responding, drain what is left
+    while !done {
+        let res = next.as_mut().await;
+        done = res.0.done;
+        send(id, &events, res.0);
+        if !done {
+            next.set(HelmCallImpl::next_events(EventsRequest { id }));
+        }
+    }
+
+    output
+}
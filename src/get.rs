@@ -0,0 +1,83 @@
+use crate::{GetRequest, HelmCall as _, HelmCallImpl, env::Env, error::call_error};
+
+#[derive(Clone, Copy, Debug, Default, PartialEq, Eq)]
+pub enum GetWhat {
+    #[default]
+    Values,
+    AllValues,
+    Manifest,
+    Notes,
+    Hooks,
+    Metadata,
+}
+
+impl GetWhat {
+    fn as_str(&self) -> &'static str {
+        match self {
+            GetWhat::Values => "values",
+            GetWhat::AllValues => "all_values",
+            GetWhat::Manifest => "manifest",
+            GetWhat::Notes => "notes",
+            GetWhat::Hooks => "hooks",
+            GetWhat::Metadata => "metadata",
+        }
+    }
+
+    fn is_json(&self) -> bool {
+        matches!(
+            self,
+            GetWhat::Values | GetWhat::AllValues | GetWhat::Metadata
+        )
+    }
+}
+
+#[derive(Clone, Debug, Default)]
+pub struct Get {
+    pub release_name: String,
+    pub ns: String,
+    pub what: GetWhat,
+    pub revision: i64,
+    pub env: Env,
+}
+
+impl From<Get> for GetRequest {
+    fn from(req: Get) -> Self {
+        GetRequest {
+            release_name: req.release_name,
+            ns: req.ns,
+            what: req.what.as_str().to_string(),
+            revision: req.revision,
+            env: req.env.into(),
+        }
+    }
+}
+
+#[derive(Clone, Debug)]
+pub enum GetOutput {
+    // Values and metadata, serialized as JSON
+    Json(Vec<u8>),
+    // Manifest, notes and hooks
+    Text(String),
+}
+
+call_error! {
+    pub enum GetError("get") {}
+}
+
+pub async fn get(req: Get) -> Result<GetOutput, GetError> {
+    let what = req.what;
+    let res = HelmCallImpl::get(req.into()).await;
+    if let Some(err) = res.0.err.first() {
+        return Err(GetError::new(
+            res.0.err_kind.as_str().into(),
+            err.clone(),
+            res.0.logs,
+        ));
+    }
+
+    if what.is_json() {
+        return Ok(GetOutput::Json(res.0.json));
+    }
+
+    Ok(GetOutput::Text(res.0.text))
+}
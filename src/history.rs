@@ -0,0 +1,43 @@
+use crate::{HelmCall as _, HelmCallImpl, HistoryRequest, env::Env, error::call_error};
+
+#[derive(Clone, Debug, Default)]
+pub struct History {
+    pub release_name: String,
+    pub ns: String,
+    pub max: i64,
+    pub env: Env,
+}
+
+impl From<History> for HistoryRequest {
+    fn from(req: History) -> Self {
+        HistoryRequest {
+            release_name: req.release_name,
+            ns: req.ns,
+            max: req.max,
+            env: req.env.into(),
+        }
+    }
+}
+
+call_error! {
+    pub enum HistoryError("history") {
+        response: Option<String>,
+    }
+}
+
+pub async fn history(req: History) -> Result<String, HistoryError> {
+    let res = HelmCallImpl::history(req.into()).await;
+    if let Some(err) = res.0.err.first() {
+        return Err(HistoryError::new(
+            res.0.err_kind.as_str().into(),
+            err.clone(),
+            res.0.logs,
+            match res.0.data.as_str() {
+                "" => None,
+                d => Some(d.to_string()),
+            },
+        ));
+    }
+
+    Ok(res.0.data)
+}
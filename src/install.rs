@@ -1,6 +1,12 @@
-use thiserror::Error;
-
-use crate::{HelmCall as _, HelmCallImpl, InstallRequest, env::Env};
+use crate::{
+    HelmCall as _, HelmCallImpl, InstallRequest,
+    cancel::CancelGuard,
+    chart_source::ChartSource,
+    env::Env,
+    error::{ErrorKind, call_error},
+    events::{EventSender, with_events},
+    release::{Release, release},
+};
 
 #[derive(Clone, Debug)]
 pub struct Install {
@@ -12,8 +18,25 @@ pub struct Install {
     pub timeout: Vec<i64>,
     pub create_namespace: bool,
     pub values: Vec<u8>,
+    pub values_files: Vec<String>,
+    pub set_values: Vec<String>,
+    pub set_string_values: Vec<String>,
+    pub set_json_values: Vec<String>,
+    pub set_file_values: Vec<String>,
+    pub set_literal_values: Vec<String>,
     pub dry_run: Option<String>,
+    pub atomic: bool,
+    pub wait_for_jobs: bool,
     pub env: Env,
+    // DependencyUpdate updates the dependencies missing from charts/ first
+    pub dependency_update: bool,
+    // ChartSource configures the repository or OCI registry of the chart
+    pub chart_source: ChartSource,
+    // Events receives the progress of the operation while it runs
+    pub events: Option<EventSender>,
+    // LockTimeout is how long in seconds to wait for another call of this
+    // process on the release, Some(0) fails fast and None waits for it
+    pub lock_timeout: Option<i64>,
 }
 
 impl Default for Install {
@@ -27,8 +50,20 @@ impl Default for Install {
             wait: Default::default(),
             create_namespace: Default::default(),
             values: Default::default(),
+            values_files: Default::default(),
+            set_values: Default::default(),
+            set_string_values: Default::default(),
+            set_json_values: Default::default(),
+            set_file_values: Default::default(),
+            set_literal_values: Default::default(),
             env: Default::default(),
+            dependency_update: Default::default(),
+            chart_source: Default::default(),
             dry_run: Default::default(),
+            atomic: Default::default(),
+            wait_for_jobs: Default::default(),
+            events: Default::default(),
+            lock_timeout: Default::default(),
         }
     }
 }
@@ -44,32 +79,93 @@ impl From<Install> for InstallRequest {
             timeout: req.timeout,
             create_namespace: req.create_namespace,
             values: req.values,
+            values_files: req.values_files,
+            set_values: req.set_values,
+            set_string_values: req.set_string_values,
+            set_json_values: req.set_json_values,
+            set_file_values: req.set_file_values,
+            set_literal_values: req.set_literal_values,
             dry_run: req.dry_run.into_iter().collect(),
             env: req.env.into(),
+            id: 0,
+            events: req.events.is_some(),
+            atomic: req.atomic,
+            wait_for_jobs: req.wait_for_jobs,
+            lock_timeout: req.lock_timeout.into_iter().collect(),
+            dependency_update: req.dependency_update,
+            cert_file: req.chart_source.cert_file,
+            key_file: req.chart_source.key_file,
+            ca_file: req.chart_source.ca_file,
+            insecure: req.chart_source.insecure,
+            plain_http: req.chart_source.plain_http,
+            username: req.chart_source.username,
+            password: req.chart_source.password,
+            pass_credentials_all: req.chart_source.pass_credentials_all,
+            repo_url: req.chart_source.repo_url,
+            keyring: req.chart_source.keyring,
+            verify: req.chart_source.verify,
         }
     }
 }
 
-#[derive(Error, Debug)]
-pub enum InstallError {
-    #[error("install error: {err}")]
-    Install {
+call_error! {
+    pub enum InstallError("install") {
         response: Option<String>,
+        // Revision the release ended up on, None if it does not exist
+        revision: Option<i64>,
+    }
+    // Uninstalled is returned instead of the kind variant when the failed
+    // atomic install was uninstalled
+    #[error("install failed and the release was uninstalled: {err}")]
+    Uninstalled {
         err: String,
-    },
+        revision: Option<i64>,
+        kind: ErrorKind,
+        logs: Vec<String>,
+    }
 }
 
-pub async fn install(req: Install) -> Result<String, InstallError> {
-    let res = HelmCallImpl::install(req.into()).await;
+pub async fn install(req: Install) -> Result<Release, InstallError> {
+    let guard = CancelGuard::new();
+    let events = req.events.clone();
+    let res = with_events(
+        guard.id(),
+        events,
+        HelmCallImpl::install(InstallRequest {
+            id: guard.id(),
+            ..req.into()
+        }),
+    )
+    .await;
+    guard.disarm();
     if let Some(err) = res.0.err.first() {
-        return Err(InstallError::Install {
-            response: match res.0.data.as_str() {
+        let kind = match res.0.cancelled {
+            true => ErrorKind::Cancelled,
+            false => res.0.err_kind.as_str().into(),
+        };
+        let revision = match res.0.revision {
+            0 => None,
+            r => Some(r),
+        };
+        if res.0.uninstalled {
+            return Err(InstallError::Uninstalled {
+                err: err.clone(),
+                revision,
+                kind,
+                logs: res.0.logs,
+            });
+        }
+        return Err(InstallError::new(
+            kind,
+            err.clone(),
+            res.0.logs,
+            match res.0.data.as_str() {
                 "" => None,
                 d => Some(d.to_string()),
             },
-            err: err.clone(),
-        });
+            revision,
+        ));
     }
 
-    Ok(res.0.data)
+    Ok(release(res.0.release, res.0.data, res.0.lock_wait))
 }
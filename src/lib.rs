@@ -18,21 +18,54 @@ pub mod binding {
     rust2go::r2g_include_binding!();
 }
 
+mod cancel;
+pub mod chart_source;
+pub mod clients;
 pub mod env;
+pub mod error;
+pub mod events;
+pub mod get;
+pub mod history;
 pub mod install;
 pub mod list;
+pub mod package;
+pub mod pull;
+pub mod push;
+pub mod recover_release;
 pub mod registry_login;
+pub mod release;
 pub mod repo_add;
 pub mod repo_search;
+pub mod rollback;
+pub mod status;
+pub mod template;
 pub mod uninstall;
 pub mod upgrade;
 
+pub use chart_source::ChartSource;
+pub use clients::{
+    InvalidateClients, WarmClients, WarmClientsError, invalidate_clients, warm_clients,
+};
 pub use env::Env;
+pub use error::ErrorKind;
+pub use events::{Event, EventKind, EventSender};
+pub use get::{Get, GetError, GetOutput, GetWhat, get};
+pub use history::{History, HistoryError, history};
 pub use install::{Install, InstallError, install};
-pub use list::{List, ListError, list};
+pub use list::{List, ListError, ListPage, ReleasePage, list, list_page};
+pub use package::{Package, PackageError, package};
+pub use pull::{Pull, PullError, Pulled, pull};
+pub use push::{Push, PushError, Pushed, push};
+pub use recover_release::{
+    RecoverPolicy, RecoverRelease, RecoverReleaseError, Recovered, recover_release,
+};
 pub use registry_login::{RegistryLogin, RegistryLoginError, registry_login};
+pub use release::Release;
 pub use repo_add::{RepoAdd, RepoAddError, repo_add};
 pub use repo_search::{RepoSearch, RepoSearchError, repo_search};
+pub use rollback::{Rollback, RollbackError, rollback};
+pub use status::{Readiness, ReleaseStatus, Status, StatusError, status};
+pub use template::{Rendered, Template, TemplateError, template};
 pub use uninstall::{Uninstall, UninstallError, uninstall};
 pub use upgrade::{Upgrade, UpgradeError, upgrade};
 
@@ -48,6 +81,44 @@ struct InstallRequest {
     values: Vec<u8>,
     env: HelmEnv,
     dry_run: Vec<String>,
+    // Id is used to cancel the operation through HelmCall::cancel, 0 disables it
+    id: u64,
+    // Atomic uninstalls the release if the install fails, it implies wait
+    atomic: bool,
+    wait_for_jobs: bool,
+    // ValuesFiles are local paths or urls merged in order, like `--values`
+    values_files: Vec<String>,
+    // Set lists are applied on top of the values files and the values blob,
+    // in the order json, set, set-string, set-file, set-literal
+    set_values: Vec<String>,
+    set_string_values: Vec<String>,
+    set_json_values: Vec<String>,
+    set_file_values: Vec<String>,
+    set_literal_values: Vec<String>,
+    // Events are buffered for HelmCall::next_events, using the request id
+    events: bool,
+    // LockTimeout is how long in seconds to wait for another call of this
+    // process on the release, 0 fails fast and unset waits until cancelled
+    lock_timeout: Vec<i64>,
+    // DependencyUpdate updates the dependencies missing from the charts
+    // directory before loading the chart, like `helm dependency update`
+    dependency_update: bool,
+    // Chart source options, like the helm install and upgrade flags. Username
+    // and password authenticate to the chart repository or OCI registry
+    cert_file: String,
+    key_file: String,
+    ca_file: String,
+    insecure: bool,
+    plain_http: bool,
+    username: String,
+    password: String,
+    // PassCredentialsAll sends the credentials to every domain
+    pass_credentials_all: bool,
+    // RepoURL looks the chart up in this repository
+    repo_url: String,
+    // Keyring defaults to the GnuPG public keyring of the user
+    keyring: String,
+    verify: bool,
 }
 
 #[derive(rust2go::R2G)]
@@ -63,6 +134,94 @@ struct UpgradeRequest {
     reset_values: bool,
     reuse_values: bool,
     dry_run: Vec<String>,
+    // Id is used to cancel the operation through HelmCall::cancel, 0 disables it
+    id: u64,
+    // Install the release if it does not exist yet, like `helm upgrade
+    // --install`, or if it was uninstalled with its history kept
+    install: bool,
+    // CreateNamespace is only used when the release gets installed
+    create_namespace: bool,
+    // Atomic rolls the release back if the upgrade fails, it implies wait
+    atomic: bool,
+    // CleanupOnFail deletes the resources created by a failed upgrade
+    cleanup_on_fail: bool,
+    wait_for_jobs: bool,
+    // MaxHistory limits the number of revisions kept, 0 keeps all of them
+    max_history: i64,
+    // ValuesFiles are local paths or urls merged in order, like `--values`
+    values_files: Vec<String>,
+    // Set lists are applied on top of the values files and the values blob,
+    // in the order json, set, set-string, set-file, set-literal
+    set_values: Vec<String>,
+    set_string_values: Vec<String>,
+    set_json_values: Vec<String>,
+    set_file_values: Vec<String>,
+    set_literal_values: Vec<String>,
+    // Events are buffered for HelmCall::next_events, using the request id
+    events: bool,
+    // LockTimeout is how long in seconds to wait for another call of this
+    // process on the release, 0 fails fast and unset waits until cancelled
+    lock_timeout: Vec<i64>,
+    // RecoverPending recovers the release with RecoverPolicy before upgrading,
+    // when its latest revision is pending since RecoverPendingAfter seconds
+    recover_pending: bool,
+    recover_policy: String,
+    recover_pending_after: i64,
+    // DependencyUpdate updates the dependencies missing from the charts
+    // directory before loading the chart, like `helm dependency update`
+    dependency_update: bool,
+    // Chart source options, like the helm install and upgrade flags. Username
+    // and password authenticate to the chart repository or OCI registry
+    cert_file: String,
+    key_file: String,
+    ca_file: String,
+    insecure: bool,
+    plain_http: bool,
+    username: String,
+    password: String,
+    // PassCredentialsAll sends the credentials to every domain
+    pass_credentials_all: bool,
+    // RepoURL looks the chart up in this repository
+    repo_url: String,
+    // Keyring defaults to the GnuPG public keyring of the user
+    keyring: String,
+    verify: bool,
+}
+
+#[derive(rust2go::R2G)]
+struct TemplateRequest {
+    release_name: String,
+    chart: String,
+    version: String,
+    ns: String,
+    values: Vec<u8>,
+    // KubeVersion overrides the kubernetes version used for Capabilities.KubeVersion
+    kube_version: Vec<String>,
+    // ApiVersions are added to the default Capabilities.APIVersions
+    api_versions: Vec<String>,
+    include_crds: bool,
+    // IsUpgrade renders the chart with .Release.IsUpgrade set
+    is_upgrade: bool,
+    env: HelmEnv,
+    // DependencyUpdate updates the dependencies missing from the charts
+    // directory before loading the chart, like `helm dependency update`
+    dependency_update: bool,
+    // Chart source options, like the helm template flags. Username and
+    // password authenticate to the chart repository or OCI registry
+    cert_file: String,
+    key_file: String,
+    ca_file: String,
+    insecure: bool,
+    plain_http: bool,
+    username: String,
+    password: String,
+    // PassCredentialsAll sends the credentials to every domain
+    pass_credentials_all: bool,
+    // RepoURL looks the chart up in this repository
+    repo_url: String,
+    // Keyring defaults to the GnuPG public keyring of the user
+    keyring: String,
+    verify: bool,
 }
 
 #[derive(rust2go::R2G)]
@@ -78,25 +237,127 @@ struct HelmEnv {
     // KubeInsecureSkipTLSVerify indicates if server's certificate will not be checked for validity.
     // This makes the HTTPS connections insecure
     kube_insecure_skip_tls_verify: bool,
+    // KubeAPIServer overrides the address of the kubernetes api server
+    kube_api_server: Vec<String>,
+    // KubeAsUser is the username to impersonate for the operation
+    kube_as_user: Vec<String>,
+    // KubeAsGroups are the groups to impersonate for the operation
+    kube_as_groups: Vec<String>,
+    // KubeTLSServerName is the server name used to validate the api server
+    // certificate, it defaults to the hostname of the api server
+    kube_tls_server_name: Vec<String>,
+    // BurstLimit is the client-side throttling limit, it defaults to HELM_BURST_LIMIT
+    burst_limit: Vec<i64>,
+    // QPS is the queries per second of the client, it defaults to HELM_QPS
+    qps: Vec<f32>,
+    // KubeConfigData is an inline kubeconfig, used instead of the kubeconfig files
+    kube_config_data: Vec<u8>,
+    // KubeCaData is the inline PEM certificate authority of the api server
+    kube_ca_data: Vec<u8>,
+    // KubeClientCertData and KubeClientKeyData are the inline PEM client
+    // certificate and key
+    kube_client_cert_data: Vec<u8>,
+    kube_client_key_data: Vec<u8>,
+    // KubeInCluster uses the service account of the pod, the inline options
+    // override it
+    kube_in_cluster: bool,
+    // Debug enables helm debug output, it lowers the log level to debug
+    debug: bool,
+    // LogLevel of the lines returned in the response logs: debug, info, warning or error
+    log_level: Vec<String>,
+    // Driver is the release storage driver: secret, configmap, sql or memory,
+    // it defaults to HELM_DRIVER. The memory driver persists across calls
+    driver: Vec<String>,
+    // DriverConnection is the sql driver connection string, it defaults to
+    // HELM_DRIVER_SQL_CONNECTION_STRING
+    driver_connection: Vec<String>,
+    // FakeKubeClient runs the calls without a cluster, for tests: printing
+    // accepts every change, failing rejects them. It defaults to the memory driver
+    fake_kube_client: Vec<String>,
+    // ClientCacheTTL is how long in seconds the kube clients are reused across
+    // calls, unset or 0 disables the cache. Only the rest client, discovery
+    // and rest mapper are cached, a changed kubeconfig file is not read again
+    // before they expire or are invalidated
+    client_cache_ttl: Vec<i64>,
+}
+
+#[derive(rust2go::R2G)]
+struct ReleaseSummary {
+    name: String,
+    namespace: String,
+    revision: i64,
+    status: String,
+    chart_name: String,
+    chart_version: String,
+    app_version: String,
+    // FirstDeployed and LastDeployed are RFC 3339 timestamps, empty if unset
+    first_deployed: String,
+    last_deployed: String,
+    description: String,
+    notes: String,
 }
 
 #[derive(rust2go::R2G)]
 struct InstallResponse {
     err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
     data: String,
+    // Cancelled is set when the operation was stopped through HelmCall::cancel
+    cancelled: bool,
+    // Uninstalled is set when a failed atomic install was uninstalled
+    uninstalled: bool,
+    // Revision the release ended up on, 0 if it does not exist
+    revision: i64,
+    // Release summarizes data, it is empty on error
+    release: Vec<ReleaseSummary>,
+    // LockWait is how long in milliseconds the call waited for the release lock
+    lock_wait: i64,
+}
+
+#[derive(rust2go::R2G)]
+struct TemplateResponse {
+    err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
+    // Manifest is the rendered manifest stream without hooks
+    manifest: String,
+    notes: String,
+    // Hooks is the rendered stream of hook manifests
+    hooks: String,
 }
 
 #[derive(rust2go::R2G)]
 struct UpgradeResponse {
     err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
     data: String,
+    // Cancelled is set when the operation was stopped through HelmCall::cancel
+    cancelled: bool,
+    // RolledBack is set when a failed atomic upgrade was rolled back
+    rolled_back: bool,
+    // Uninstalled is set when a failed atomic upgrade --install was uninstalled
+    uninstalled: bool,
+    // Revision the release ended up on, 0 if it does not exist
+    revision: i64,
+    // Release summarizes data, it is empty on error
+    release: Vec<ReleaseSummary>,
+    // LockWait is how long in milliseconds the call waited for the release lock
+    lock_wait: i64,
 }
 
 #[derive(rust2go::R2G)]
 struct ListRequest {
     ns: String,
     env: HelmEnv,
-    // All ignores the limit/offset
+    // All lists the releases in every state, like `helm list --all`
     all: bool,
     // AllNamespaces searches across namespaces
     all_namespaces: bool,
@@ -130,7 +391,12 @@ struct ListRequest {
 #[derive(rust2go::R2G)]
 struct ListResponse {
     err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
     data: String,
+    releases: Vec<ReleaseSummary>,
 }
 
 #[derive(rust2go::R2G)]
@@ -146,6 +412,10 @@ struct SearchRequest {
 #[derive(rust2go::R2G)]
 struct SearchResponse {
     err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
     data: String,
 }
 
@@ -170,6 +440,96 @@ struct AddRequest {
 #[derive(rust2go::R2G)]
 struct AddResponse {
     err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
+}
+
+#[derive(rust2go::R2G)]
+struct RecoverRequest {
+    ns: String,
+    release_name: String,
+    // Policy applied to a pending latest revision: mark_failed, rollback or
+    // delete, it defaults to mark_failed
+    policy: String,
+    // MinAge skips pending revisions younger than it in seconds, they may
+    // still be in progress in another process
+    min_age: i64,
+    // Wait and Timeout apply to the rollback policy
+    wait: bool,
+    timeout: Vec<i64>,
+    // Id is used to cancel the operation through HelmCall::cancel, 0 disables it
+    id: u64,
+    // LockTimeout is how long in seconds to wait for another call of this
+    // process on the release, 0 fails fast and unset waits until cancelled
+    lock_timeout: Vec<i64>,
+
+    env: HelmEnv,
+}
+
+#[derive(rust2go::R2G)]
+struct RecoverResponse {
+    err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
+    data: String,
+    // Cancelled is set when the operation was stopped through HelmCall::cancel
+    cancelled: bool,
+    // Status of the latest revision before the recovery
+    status: String,
+    // Recovered is set when a pending revision was recovered
+    recovered: bool,
+    // Release summarizes data, it is empty when the recovery deleted the
+    // only revision
+    release: Vec<ReleaseSummary>,
+    // LockWait is how long in milliseconds the call waited for the release lock
+    lock_wait: i64,
+}
+
+#[derive(rust2go::R2G)]
+struct ListPageRequest {
+    ns: String,
+    env: HelmEnv,
+    // All lists the releases in every state, like `helm list --all`
+    all: bool,
+    // AllNamespaces searches across namespaces
+    all_namespaces: bool,
+    // StateMask accepts a bitmask of states, it takes precedence over the
+    // state flags
+    state_mask: u64,
+    deployed: bool,
+    failed: bool,
+    pending: bool,
+    uninstalled: bool,
+    uninstalling: bool,
+    superseded: bool,
+    // Filter is a regular expression matched against the release names
+    filter: String,
+    selector: String,
+    // The releases are sorted by name then namespace, ByDate sorts them by
+    // last deployment with the latest first, SortReverse reverses the order
+    by_date: bool,
+    sort_reverse: bool,
+    // PageSize is the maximum number of releases returned, it defaults to 100
+    page_size: i64,
+    // Cursor is the next_cursor of the previous page, empty for the first one
+    cursor: String,
+}
+
+#[derive(rust2go::R2G)]
+struct ListPageResponse {
+    err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
+    releases: Vec<ReleaseSummary>,
+    // NextCursor continues the listing after this page, it is empty on the
+    // last page
+    next_cursor: String,
 }
 
 #[derive(rust2go::R2G)]
@@ -186,12 +546,146 @@ struct UninstallRequest {
     description: String,
 
     env: HelmEnv,
+    // Id is used to cancel the operation through HelmCall::cancel, 0 disables it
+    id: u64,
+    // Events are buffered for HelmCall::next_events, using the request id
+    events: bool,
+    // LockTimeout is how long in seconds to wait for another call of this
+    // process on the release, 0 fails fast and unset waits until cancelled
+    lock_timeout: Vec<i64>,
 }
 
 #[derive(rust2go::R2G)]
 struct UninstallResponse {
     err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
     data: String,
+    // Cancelled is set when the operation was stopped through HelmCall::cancel
+    cancelled: bool,
+    // Release summarizes data, it is empty on error
+    release: Vec<ReleaseSummary>,
+    // LockWait is how long in milliseconds the call waited for the release lock
+    lock_wait: i64,
+}
+
+#[derive(rust2go::R2G)]
+struct StatusRequest {
+    ns: String,
+    release_name: String,
+    // Revision of the release, 0 selects the latest one
+    revision: i64,
+    // ShowResources fetches the live release resources and their readiness
+    show_resources: bool,
+
+    env: HelmEnv,
+}
+
+#[derive(rust2go::R2G)]
+struct ResourceReadiness {
+    kind: String,
+    name: String,
+    namespace: String,
+    ready: bool,
+    // Reason explains why the resource is not ready
+    reason: String,
+}
+
+#[derive(rust2go::R2G)]
+struct StatusResponse {
+    err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
+    data: String,
+    resources: Vec<ResourceReadiness>,
+}
+
+#[derive(rust2go::R2G)]
+struct GetRequest {
+    ns: String,
+    release_name: String,
+    // What selects the output: values, all_values, manifest, notes, hooks or metadata
+    what: String,
+    // Revision of the release, 0 selects the latest one
+    revision: i64,
+
+    env: HelmEnv,
+}
+
+#[derive(rust2go::R2G)]
+struct GetResponse {
+    err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
+    // Json holds values and metadata
+    json: Vec<u8>,
+    // Text holds the manifest, notes and hooks
+    text: String,
+}
+
+#[derive(rust2go::R2G)]
+struct HistoryRequest {
+    ns: String,
+    release_name: String,
+    // Max is the maximum number of revisions to return, 0 returns all of them
+    max: i64,
+
+    env: HelmEnv,
+}
+
+#[derive(rust2go::R2G)]
+struct HistoryResponse {
+    err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
+    data: String,
+}
+
+#[derive(rust2go::R2G)]
+struct RollbackRequest {
+    ns: String,
+    release_name: String,
+    // Revision to roll back to, 0 selects the previous one
+    revision: i64,
+    wait: bool,
+    wait_for_jobs: bool,
+    timeout: Vec<i64>,
+    force: bool,
+    recreate: bool,
+    cleanup_on_fail: bool,
+    disable_hooks: bool,
+    dry_run: bool,
+
+    env: HelmEnv,
+    // Id is used to cancel the operation through HelmCall::cancel, 0 disables it
+    id: u64,
+    // LockTimeout is how long in seconds to wait for another call of this
+    // process on the release, 0 fails fast and unset waits until cancelled
+    lock_timeout: Vec<i64>,
+}
+
+#[derive(rust2go::R2G)]
+struct RollbackResponse {
+    err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
+    data: String,
+    // Cancelled is set when the operation was stopped through HelmCall::cancel
+    cancelled: bool,
+    // Release summarizes data, it is empty on failures
+    release: Vec<ReleaseSummary>,
+    // LockWait is how long in milliseconds the call waited for the release lock
+    lock_wait: i64,
 }
 
 #[derive(rust2go::R2G)]
@@ -211,6 +705,175 @@ struct LoginRequest {
 #[derive(rust2go::R2G)]
 struct LoginResponse {
     err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
+}
+
+#[derive(rust2go::R2G)]
+struct PullRequest {
+    chart: String,
+    version: String,
+    // DestDir is where the chart is saved, it defaults to the current directory
+    dest_dir: String,
+    // Untar expands the chart into UntarDir, relative to DestDir, instead of
+    // saving the archive
+    untar: bool,
+    untar_dir: String,
+    // Prov also saves the provenance file, without verifying it
+    prov: bool,
+    // Devel uses development versions too, when no version is set
+    devel: bool,
+    cert_file: String,
+    key_file: String,
+    ca_file: String,
+    insecure: bool,
+    plain_http: bool,
+    username: String,
+    password: String,
+    // PassCredentialsAll sends the credentials to every domain
+    pass_credentials_all: bool,
+    // RepoURL looks the chart up in this repository
+    repo_url: String,
+    // Keyring defaults to the GnuPG public keyring of the user
+    keyring: String,
+    verify: bool,
+
+    env: HelmEnv,
+}
+
+#[derive(rust2go::R2G)]
+struct PullResponse {
+    err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
+    // Path is the chart archive, or the chart directory when untarred
+    path: String,
+    // Digest is the manifest digest of OCI charts, empty otherwise
+    digest: String,
+}
+
+#[derive(rust2go::R2G)]
+struct PushRequest {
+    // Chart is the path of the chart archive, a provenance file next to it is
+    // pushed along
+    chart: String,
+    // Remote is the oci:// repository the chart is pushed under
+    remote: String,
+    cert_file: String,
+    key_file: String,
+    ca_file: String,
+    insecure: bool,
+    plain_http: bool,
+
+    env: HelmEnv,
+}
+
+#[derive(rust2go::R2G)]
+struct PushResponse {
+    err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
+    // Ref is the pushed reference, without the oci:// scheme
+    pushed_ref: String,
+    digest: String,
+}
+
+#[derive(rust2go::R2G)]
+struct PackageRequest {
+    // Path is the chart directory
+    path: String,
+    // Destination is where the archive is written, it defaults to the
+    // current directory
+    destination: String,
+    // Version and AppVersion override the ones of Chart.yaml
+    version: String,
+    app_version: String,
+    // DependencyUpdate updates the dependencies in the charts directory
+    // before packaging
+    dependency_update: bool,
+    // Sign writes a provenance file next to the archive, signed with Key from
+    // Keyring, Keyring defaults to the GnuPG keyring of the user
+    sign: bool,
+    key: String,
+    keyring: String,
+    passphrase_file: String,
+
+    env: HelmEnv,
+}
+
+#[derive(rust2go::R2G)]
+struct PackageResponse {
+    err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
+    // Path is the chart archive
+    path: String,
+}
+
+#[derive(rust2go::R2G)]
+struct ProgressEvent {
+    // Kind is phase, log or wait
+    kind: String,
+    // Phase the operation was in: locating, loading, dependencies, rendering,
+    // applying, hooks, waiting or uninstalling
+    phase: String,
+    message: String,
+    // Resource is kind/namespace/name for wait events
+    resource: String,
+    // Time in milliseconds since the unix epoch
+    time: i64,
+}
+
+#[derive(rust2go::R2G)]
+struct EventsRequest {
+    // Id of the install, upgrade or uninstall request to get the events of
+    id: u64,
+}
+
+#[derive(rust2go::R2G)]
+struct EventsResponse {
+    events: Vec<ProgressEvent>,
+    // Done is set once the operation finished and all events were returned
+    done: bool,
+}
+
+#[derive(rust2go::R2G)]
+struct WarmClientsRequest {
+    // Ns is the namespace the clients are used for, like in the other requests
+    ns: String,
+    env: HelmEnv,
+}
+
+#[derive(rust2go::R2G)]
+struct WarmClientsResponse {
+    err: Vec<String>,
+    // ErrKind classifies the error, it is empty when the cause is unknown
+    err_kind: String,
+    // Logs are the lines logged while handling the request
+    logs: Vec<String>,
+}
+
+#[derive(rust2go::R2G)]
+struct InvalidateClientsRequest {
+    ns: String,
+    env: HelmEnv,
+    // All forgets the clients of every cluster, ns and env are ignored
+    all: bool,
+}
+
+#[derive(rust2go::R2G)]
+struct CancelRequest {
+    // Id of the install, upgrade, uninstall, rollback or recover request to
+    // cancel
+    id: u64,
 }
 
 // Define the call trait.
@@ -228,13 +891,42 @@ trait HelmCall {
     #[drop_safe_ret]
     async fn upgrade(req: UpgradeRequest) -> UpgradeResponse;
     #[drop_safe_ret]
+    async fn template(req: TemplateRequest) -> TemplateResponse;
+    #[drop_safe_ret]
     async fn uninstall(req: UninstallRequest) -> UninstallResponse;
     #[drop_safe_ret]
     async fn list(req: ListRequest) -> ListResponse;
     #[drop_safe_ret]
+    async fn list_page(req: ListPageRequest) -> ListPageResponse;
+    #[drop_safe_ret]
+    async fn status(req: StatusRequest) -> StatusResponse;
+    #[drop_safe_ret]
+    async fn get(req: GetRequest) -> GetResponse;
+    #[drop_safe_ret]
+    async fn history(req: HistoryRequest) -> HistoryResponse;
+    #[drop_safe_ret]
+    async fn rollback(req: RollbackRequest) -> RollbackResponse;
+    #[drop_safe_ret]
+    async fn recover_release(req: RecoverRequest) -> RecoverResponse;
+    #[drop_safe_ret]
     async fn repo_add(req: AddRequest) -> AddResponse;
     #[drop_safe_ret]
     async fn repo_search(req: SearchRequest) -> SearchResponse;
     #[drop_safe_ret]
     async fn registry_login(req: LoginRequest) -> LoginResponse;
+    #[drop_safe_ret]
+    async fn pull(req: PullRequest) -> PullResponse;
+    #[drop_safe_ret]
+    async fn push(req: PushRequest) -> PushResponse;
+    #[drop_safe_ret]
+    async fn package_chart(req: PackageRequest) -> PackageResponse;
+    #[drop_safe_ret]
+    async fn next_events(req: EventsRequest) -> EventsResponse;
+    #[drop_safe_ret]
+    async fn warm_clients(req: WarmClientsRequest) -> WarmClientsResponse;
+    fn invalidate_clients(req: &InvalidateClientsRequest);
+    // OpenEvents registers the stream of a request before it is sent, so
+    // next_events knows about it
+    fn open_events(req: &EventsRequest);
+    fn cancel(req: &CancelRequest);
 }
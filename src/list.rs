@@ -1,6 +1,7 @@
-use thiserror::Error;
-
-use crate::{HelmCall as _, HelmCallImpl, ListRequest, env::Env};
+use crate::{
+    HelmCall as _, HelmCallImpl, ListPageRequest, ListRequest, env::Env, error::call_error,
+    release::Release,
+};
 
 #[derive(Clone, Debug, Default)]
 pub struct List {
@@ -53,26 +54,101 @@ impl From<List> for ListRequest {
     }
 }
 
-#[derive(Error, Debug)]
-pub enum ListError {
-    #[error("list error: {err}")]
-    List {
+// ListPage lists the releases a page at a time, the pages stay consistent
+// when releases are added or removed between the calls.
+#[derive(Clone, Debug, Default)]
+pub struct ListPage {
+    pub all: bool,
+    pub all_namespaces: bool,
+    pub state_mask: u64,
+    pub deployed: bool,
+    pub failed: bool,
+    pub pending: bool,
+    pub uninstalled: bool,
+    pub uninstalling: bool,
+    pub superseded: bool,
+    pub filter: String,
+    pub selector: String,
+    pub by_date: bool,
+    pub sort_reverse: bool,
+    // 0 uses the default page size
+    pub page_size: i64,
+    // The next_cursor of the previous page, None for the first one
+    pub cursor: Option<String>,
+    pub ns: String,
+    pub env: Env,
+}
+
+impl From<ListPage> for ListPageRequest {
+    fn from(req: ListPage) -> Self {
+        ListPageRequest {
+            all: req.all,
+            all_namespaces: req.all_namespaces,
+            state_mask: req.state_mask,
+            deployed: req.deployed,
+            failed: req.failed,
+            pending: req.pending,
+            uninstalled: req.uninstalled,
+            uninstalling: req.uninstalling,
+            superseded: req.superseded,
+            filter: req.filter,
+            selector: req.selector,
+            by_date: req.by_date,
+            sort_reverse: req.sort_reverse,
+            page_size: req.page_size,
+            cursor: req.cursor.unwrap_or_default(),
+            ns: req.ns,
+            env: req.env.into(),
+        }
+    }
+}
+
+#[derive(Clone, Debug)]
+pub struct ReleasePage {
+    pub releases: Vec<Release>,
+    // Continues the listing with ListPage::cursor, None on the last page
+    pub next_cursor: Option<String>,
+}
+
+call_error! {
+    pub enum ListError("list") {
         response: Option<String>,
-        err: String,
-    },
+    }
 }
 
-pub async fn list(req: List) -> Result<String, ListError> {
+pub async fn list(req: List) -> Result<Vec<Release>, ListError> {
     let res = HelmCallImpl::list(req.into()).await;
     if let Some(err) = res.0.err.first() {
-        return Err(ListError::List {
-            response: match res.0.data.as_str() {
+        return Err(ListError::new(
+            res.0.err_kind.as_str().into(),
+            err.clone(),
+            res.0.logs,
+            match res.0.data.as_str() {
                 "" => None,
                 d => Some(d.to_string()),
             },
-            err: err.clone(),
-        });
+        ));
+    }
+
+    Ok(res.0.releases.into_iter().map(Release::from).collect())
+}
+
+pub async fn list_page(req: ListPage) -> Result<ReleasePage, ListError> {
+    let res = HelmCallImpl::list_page(req.into()).await;
+    if let Some(err) = res.0.err.first() {
+        return Err(ListError::new(
+            res.0.err_kind.as_str().into(),
+            err.clone(),
+            res.0.logs,
+            None,
+        ));
     }
 
-    Ok(res.0.data)
+    Ok(ReleasePage {
+        releases: res.0.releases.into_iter().map(Release::from).collect(),
+        next_cursor: match res.0.next_cursor.as_str() {
+            "" => None,
+            _ => Some(res.0.next_cursor),
+        },
+    })
 }
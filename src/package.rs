@@ -0,0 +1,55 @@
+use crate::{HelmCall as _, HelmCallImpl, PackageRequest, env::Env, error::call_error};
+
+#[derive(Clone, Debug, Default)]
+pub struct Package {
+    // The chart directory
+    pub path: String,
+    // The archive is written to the current directory when unset
+    pub destination: Option<String>,
+    // Override the version and app version of Chart.yaml
+    pub version: Option<String>,
+    pub app_version: Option<String>,
+    pub dependency_update: bool,
+    // Sign writes a provenance file next to the archive, signed with key
+    pub sign: bool,
+    pub key: String,
+    // The GnuPG keyring of the user is used when unset
+    pub keyring: Option<String>,
+    pub passphrase_file: Option<String>,
+    pub env: Env,
+}
+
+impl From<Package> for PackageRequest {
+    fn from(req: Package) -> Self {
+        PackageRequest {
+            path: req.path,
+            destination: req.destination.unwrap_or_default(),
+            version: req.version.unwrap_or_default(),
+            app_version: req.app_version.unwrap_or_default(),
+            dependency_update: req.dependency_update,
+            sign: req.sign,
+            key: req.key,
+            keyring: req.keyring.unwrap_or_default(),
+            passphrase_file: req.passphrase_file.unwrap_or_default(),
+            env: req.env.into(),
+        }
+    }
+}
+
+call_error! {
+    pub enum PackageError("package") {}
+}
+
+// package returns the path of the chart archive
+pub async fn package(req: Package) -> Result<String, PackageError> {
+    let res = HelmCallImpl::package_chart(req.into()).await;
+    if let Some(err) = res.0.err.first() {
+        return Err(PackageError::new(
+            res.0.err_kind.as_str().into(),
+            err.clone(),
+            res.0.logs,
+        ));
+    }
+
+    Ok(res.0.path)
+}
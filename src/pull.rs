@@ -0,0 +1,78 @@
+use crate::{
+    HelmCall as _, HelmCallImpl, PullRequest, chart_source::ChartSource, env::Env,
+    error::call_error,
+};
+
+#[derive(Clone, Debug, Default)]
+pub struct Pull {
+    pub chart: String,
+    pub version: String,
+    // The chart is saved in the current directory when unset
+    pub dest_dir: Option<String>,
+    // Untar expands the chart into untar_dir, relative to dest_dir
+    pub untar: bool,
+    pub untar_dir: Option<String>,
+    // Prov also saves the provenance file, without verifying it
+    pub prov: bool,
+    pub devel: bool,
+    // ChartSource configures the repository or OCI registry of the chart
+    pub chart_source: ChartSource,
+    pub env: Env,
+}
+
+impl From<Pull> for PullRequest {
+    fn from(req: Pull) -> Self {
+        PullRequest {
+            chart: req.chart,
+            version: req.version,
+            dest_dir: req.dest_dir.unwrap_or_default(),
+            untar: req.untar,
+            untar_dir: req.untar_dir.unwrap_or_default(),
+            prov: req.prov,
+            devel: req.devel,
+            cert_file: req.chart_source.cert_file,
+            key_file: req.chart_source.key_file,
+            ca_file: req.chart_source.ca_file,
+            insecure: req.chart_source.insecure,
+            plain_http: req.chart_source.plain_http,
+            username: req.chart_source.username,
+            password: req.chart_source.password,
+            pass_credentials_all: req.chart_source.pass_credentials_all,
+            repo_url: req.chart_source.repo_url,
+            keyring: req.chart_source.keyring,
+            verify: req.chart_source.verify,
+            env: req.env.into(),
+        }
+    }
+}
+
+#[derive(Clone, Debug, Default)]
+pub struct Pulled {
+    // The chart archive, or the chart directory when untarred
+    pub path: String,
+    // The manifest digest of charts pulled from an OCI registry
+    pub digest: Option<String>,
+}
+
+call_error! {
+    pub enum PullError("pull") {}
+}
+
+pub async fn pull(req: Pull) -> Result<Pulled, PullError> {
+    let res = HelmCallImpl::pull(req.into()).await;
+    if let Some(err) = res.0.err.first() {
+        return Err(PullError::new(
+            res.0.err_kind.as_str().into(),
+            err.clone(),
+            res.0.logs,
+        ));
+    }
+
+    Ok(Pulled {
+        path: res.0.path,
+        digest: match res.0.digest.as_str() {
+            "" => None,
+            _ => Some(res.0.digest),
+        },
+    })
+}
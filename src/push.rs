@@ -0,0 +1,57 @@
+use crate::{HelmCall as _, HelmCallImpl, PushRequest, env::Env, error::call_error};
+
+#[derive(Clone, Debug, Default)]
+pub struct Push {
+    // The chart archive, a provenance file next to it is pushed along
+    pub chart: String,
+    // The oci:// repository the chart is pushed under
+    pub remote: String,
+    pub cert_file: String,
+    pub key_file: String,
+    pub ca_file: String,
+    pub insecure: bool,
+    pub plain_http: bool,
+    pub env: Env,
+}
+
+impl From<Push> for PushRequest {
+    fn from(req: Push) -> Self {
+        PushRequest {
+            chart: req.chart,
+            remote: req.remote,
+            cert_file: req.cert_file,
+            key_file: req.key_file,
+            ca_file: req.ca_file,
+            insecure: req.insecure,
+            plain_http: req.plain_http,
+            env: req.env.into(),
+        }
+    }
+}
+
+#[derive(Clone, Debug, Default)]
+pub struct Pushed {
+    // The pushed reference, without the oci:// scheme
+    pub pushed_ref: String,
+    pub digest: String,
+}
+
+call_error! {
+    pub enum PushError("push") {}
+}
+
+pub async fn push(req: Push) -> Result<Pushed, PushError> {
+    let res = HelmCallImpl::push(req.into()).await;
+    if let Some(err) = res.0.err.first() {
+        return Err(PushError::new(
+            res.0.err_kind.as_str().into(),
+            err.clone(),
+            res.0.logs,
+        ));
+    }
+
+    Ok(Pushed {
+        pushed_ref: res.0.pushed_ref,
+        digest: res.0.digest,
+    })
+}
@@ -0,0 +1,120 @@
+use crate::{
+    HelmCall as _, HelmCallImpl, RecoverRequest,
+    cancel::CancelGuard,
+    env::Env,
+    error::{ErrorKind, call_error},
+    release::{Release, release},
+};
+
+// RecoverPolicy restores a release stuck in a pending status after the
+// process running the operation was killed
+#[derive(Clone, Copy, Debug, Default, PartialEq, Eq)]
+pub enum RecoverPolicy {
+    // MarkFailed marks the pending revision as failed, the next upgrade
+    // starts from it
+    #[default]
+    MarkFailed,
+    // Rollback marks the pending revision as failed and rolls back to the
+    // last deployed revision
+    Rollback,
+    // Delete deletes the pending revision, the previous one becomes the
+    // latest again
+    Delete,
+}
+
+impl RecoverPolicy {
+    pub(crate) fn as_str(&self) -> &'static str {
+        match self {
+            RecoverPolicy::MarkFailed => "mark_failed",
+            RecoverPolicy::Rollback => "rollback",
+            RecoverPolicy::Delete => "delete",
+        }
+    }
+}
+
+#[derive(Clone, Debug)]
+pub struct RecoverRelease {
+    pub release_name: String,
+    pub ns: String,
+    pub policy: RecoverPolicy,
+    // MinAge skips pending revisions younger than it in seconds
+    pub min_age: i64,
+    pub wait: bool,
+    pub timeout: Vec<i64>,
+    // LockTimeout is how long in seconds to wait for another call of this
+    // process on the release, Some(0) fails fast and None waits for it
+    pub lock_timeout: Option<i64>,
+    pub env: Env,
+}
+
+impl Default for RecoverRelease {
+    fn default() -> Self {
+        RecoverRelease {
+            timeout: vec![300],
+            release_name: Default::default(),
+            ns: Default::default(),
+            policy: Default::default(),
+            min_age: Default::default(),
+            wait: Default::default(),
+            lock_timeout: Default::default(),
+            env: Default::default(),
+        }
+    }
+}
+
+impl From<RecoverRelease> for RecoverRequest {
+    fn from(req: RecoverRelease) -> Self {
+        RecoverRequest {
+            release_name: req.release_name,
+            ns: req.ns,
+            policy: req.policy.as_str().to_string(),
+            min_age: req.min_age,
+            wait: req.wait,
+            timeout: req.timeout,
+            id: 0,
+            lock_timeout: req.lock_timeout.into_iter().collect(),
+            env: req.env.into(),
+        }
+    }
+}
+
+#[derive(Clone, Debug, Default)]
+pub struct Recovered {
+    // Status of the latest revision before the recovery
+    pub status: String,
+    // Recovered is set when a pending revision was recovered
+    pub recovered: bool,
+    // Release is the latest revision after the recovery, None when the delete
+    // policy removed the only revision
+    pub release: Option<Release>,
+}
+
+call_error! {
+    pub enum RecoverReleaseError("recover release") {}
+}
+
+pub async fn recover_release(req: RecoverRelease) -> Result<Recovered, RecoverReleaseError> {
+    let guard = CancelGuard::new();
+    let res = HelmCallImpl::recover_release(RecoverRequest {
+        id: guard.id(),
+        ..req.into()
+    })
+    .await;
+    guard.disarm();
+    if let Some(err) = res.0.err.first() {
+        let kind = match res.0.cancelled {
+            true => ErrorKind::Cancelled,
+            false => res.0.err_kind.as_str().into(),
+        };
+        return Err(RecoverReleaseError::new(kind, err.clone(), res.0.logs));
+    }
+
+    Ok(Recovered {
+        status: res.0.status,
+        recovered: res.0.recovered,
+        release: match res.0.release.is_empty() {
+            true => None,
+            false => Some(release(res.0.release, res.0.data, res.0.lock_wait)),
+        },
+    })
+}
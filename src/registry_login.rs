@@ -1,6 +1,4 @@
-use thiserror::Error;
-
-use crate::{HelmCall as _, HelmCallImpl, LoginRequest, env::Env};
+use crate::{HelmCall as _, HelmCallImpl, LoginRequest, env::Env, error::call_error};
 
 #[derive(Clone, Debug, Default)]
 pub struct RegistryLogin {
@@ -31,20 +29,18 @@ impl From<RegistryLogin> for LoginRequest {
     }
 }
 
-#[derive(Error, Debug)]
-pub enum RegistryLoginError {
-    #[error("registry login error: {err}")]
-    RegistryLogin {
-        err: String,
-    },
+call_error! {
+    pub enum RegistryLoginError("registry login") {}
 }
 
 pub async fn registry_login(req: RegistryLogin) -> Result<(), RegistryLoginError> {
     let res = HelmCallImpl::registry_login(req.into()).await;
     if let Some(err) = res.0.err.first() {
-        return Err(RegistryLoginError::RegistryLogin {
-            err: err.clone(),
-        });
+        return Err(RegistryLoginError::new(
+            res.0.err_kind.as_str().into(),
+            err.clone(),
+            res.0.logs,
+        ));
     }
 
     Ok(())
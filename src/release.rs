@@ -0,0 +1,61 @@
+use std::time::Duration;
+
+use crate::ReleaseSummary;
+
+#[derive(Clone, Debug, Default)]
+pub struct Release {
+    pub name: String,
+    pub namespace: String,
+    pub revision: i64,
+    pub status: String,
+    pub chart_name: String,
+    pub chart_version: String,
+    pub app_version: String,
+    // FirstDeployed and LastDeployed are RFC 3339 timestamps, empty if unset
+    pub first_deployed: String,
+    pub last_deployed: String,
+    pub description: String,
+    pub notes: String,
+    // Json is the full release as serialized by helm, it is not set for list
+    pub json: Option<String>,
+    // LockWait is how long install, upgrade or uninstall waited for the
+    // release lock
+    pub lock_wait: Duration,
+}
+
+impl From<ReleaseSummary> for Release {
+    fn from(summary: ReleaseSummary) -> Self {
+        Release {
+            name: summary.name,
+            namespace: summary.namespace,
+            revision: summary.revision,
+            status: summary.status,
+            chart_name: summary.chart_name,
+            chart_version: summary.chart_version,
+            app_version: summary.app_version,
+            first_deployed: summary.first_deployed,
+            last_deployed: summary.last_deployed,
+            description: summary.description,
+            notes: summary.notes,
+            json: None,
+            lock_wait: Duration::ZERO,
+        }
+    }
+}
+
+// release builds the release returned by install, upgrade and uninstall from
+// the summary, the json data and the lock wait of the response.
+pub(crate) fn release(summary: Vec<ReleaseSummary>, data: String, lock_wait: i64) -> Release {
+    Release {
+        lock_wait: Duration::from_millis(lock_wait.max(0) as u64),
+        json: match data.as_str() {
+            "" => None,
+            _ => Some(data),
+        },
+        ..summary
+            .into_iter()
+            .next()
+            .map(Release::from)
+            .unwrap_or_default()
+    }
+}
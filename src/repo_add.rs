@@ -1,6 +1,4 @@
-use thiserror::Error;
-
-use crate::{AddRequest, HelmCall as _, HelmCallImpl, env::Env};
+use crate::{AddRequest, HelmCall as _, HelmCallImpl, env::Env, error::call_error};
 
 #[derive(Clone, Debug, Default)]
 pub struct RepoAdd {
@@ -39,22 +37,22 @@ impl From<RepoAdd> for AddRequest {
     }
 }
 
-#[derive(Error, Debug)]
-pub enum RepoAddError {
-    #[error("repo add error: {err}")]
-    RepoAdd {
+call_error! {
+    pub enum RepoAddError("repo add") {
         response: Option<String>,
-        err: String,
-    },
+    }
 }
 
 pub async fn repo_add(req: RepoAdd) -> Result<(), RepoAddError> {
     let res = HelmCallImpl::repo_add(req.into()).await;
     if let Some(err) = res.0.err.first() {
-        return Err(RepoAddError::RepoAdd {
-            response: None, // AddResponse does not have a data field
-            err: err.clone(),
-        });
+        return Err(RepoAddError::new(
+            res.0.err_kind.as_str().into(),
+            err.clone(),
+            res.0.logs,
+            // AddResponse does not have a data field
+            None,
+        ));
     }
 
     Ok(())
@@ -1,6 +1,4 @@
-use thiserror::Error;
-
-use crate::{HelmCall as _, HelmCallImpl, SearchRequest, env::Env};
+use crate::{HelmCall as _, HelmCallImpl, SearchRequest, env::Env, error::call_error};
 
 #[derive(Clone, Debug, Default)]
 pub struct RepoSearch {
@@ -25,25 +23,24 @@ impl From<RepoSearch> for SearchRequest {
     }
 }
 
-#[derive(Error, Debug)]
-pub enum RepoSearchError {
-    #[error("repo search error: {err}")]
-    RepoSearch {
+call_error! {
+    pub enum RepoSearchError("repo search") {
         response: Option<String>,
-        err: String,
-    },
+    }
 }
 
 pub async fn repo_search(req: RepoSearch) -> Result<String, RepoSearchError> {
     let res = HelmCallImpl::repo_search(req.into()).await;
     if let Some(err) = res.0.err.first() {
-        return Err(RepoSearchError::RepoSearch {
-            response: match res.0.data.as_str() {
+        return Err(RepoSearchError::new(
+            res.0.err_kind.as_str().into(),
+            err.clone(),
+            res.0.logs,
+            match res.0.data.as_str() {
                 "" => None,
                 d => Some(d.to_string()),
             },
-            err: err.clone(),
-        });
+        ));
     }
 
     Ok(res.0.data)
@@ -0,0 +1,100 @@
+use crate::{
+    HelmCall as _, HelmCallImpl, RollbackRequest,
+    cancel::CancelGuard,
+    env::Env,
+    error::{ErrorKind, call_error},
+    release::{Release, release},
+};
+
+#[derive(Clone, Debug)]
+pub struct Rollback {
+    pub release_name: String,
+    pub ns: String,
+    pub revision: i64,
+    pub wait: bool,
+    pub wait_for_jobs: bool,
+    pub timeout: Vec<i64>,
+    pub force: bool,
+    pub recreate: bool,
+    pub cleanup_on_fail: bool,
+    pub disable_hooks: bool,
+    pub dry_run: bool,
+    pub env: Env,
+    // LockTimeout is how long in seconds to wait for another call of this
+    // process on the release, Some(0) fails fast and None waits for it
+    pub lock_timeout: Option<i64>,
+}
+
+impl Default for Rollback {
+    fn default() -> Self {
+        Rollback {
+            timeout: vec![300],
+            release_name: Default::default(),
+            ns: Default::default(),
+            revision: Default::default(),
+            wait: Default::default(),
+            wait_for_jobs: Default::default(),
+            force: Default::default(),
+            recreate: Default::default(),
+            cleanup_on_fail: Default::default(),
+            disable_hooks: Default::default(),
+            dry_run: Default::default(),
+            env: Default::default(),
+            lock_timeout: Default::default(),
+        }
+    }
+}
+
+impl From<Rollback> for RollbackRequest {
+    fn from(req: Rollback) -> Self {
+        RollbackRequest {
+            release_name: req.release_name,
+            ns: req.ns,
+            revision: req.revision,
+            wait: req.wait,
+            wait_for_jobs: req.wait_for_jobs,
+            timeout: req.timeout,
+            force: req.force,
+            recreate: req.recreate,
+            cleanup_on_fail: req.cleanup_on_fail,
+            disable_hooks: req.disable_hooks,
+            dry_run: req.dry_run,
+            env: req.env.into(),
+            id: 0,
+            lock_timeout: req.lock_timeout.into_iter().collect(),
+        }
+    }
+}
+
+call_error! {
+    pub enum RollbackError("rollback") {
+        response: Option<String>,
+    }
+}
+
+pub async fn rollback(req: Rollback) -> Result<Release, RollbackError> {
+    let guard = CancelGuard::new();
+    let res = HelmCallImpl::rollback(RollbackRequest {
+        id: guard.id(),
+        ..req.into()
+    })
+    .await;
+    guard.disarm();
+    if let Some(err) = res.0.err.first() {
+        let kind = match res.0.cancelled {
+            true => ErrorKind::Cancelled,
+            false => res.0.err_kind.as_str().into(),
+        };
+        return Err(RollbackError::new(
+            kind,
+            err.clone(),
+            res.0.logs,
+            match res.0.data.as_str() {
+                "" => None,
+                d => Some(d.to_string()),
+            },
+        ));
+    }
+
+    Ok(release(res.0.release, res.0.data, res.0.lock_wait))
+}
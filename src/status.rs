@@ -0,0 +1,74 @@
+use crate::{HelmCall as _, HelmCallImpl, StatusRequest, env::Env, error::call_error};
+
+#[derive(Clone, Debug, Default)]
+pub struct Status {
+    pub release_name: String,
+    pub ns: String,
+    pub revision: i64,
+    pub show_resources: bool,
+    pub env: Env,
+}
+
+impl From<Status> for StatusRequest {
+    fn from(req: Status) -> Self {
+        StatusRequest {
+            release_name: req.release_name,
+            ns: req.ns,
+            revision: req.revision,
+            show_resources: req.show_resources,
+            env: req.env.into(),
+        }
+    }
+}
+
+#[derive(Clone, Debug, Default)]
+pub struct Readiness {
+    pub kind: String,
+    pub name: String,
+    pub namespace: String,
+    pub ready: bool,
+    pub reason: String,
+}
+
+#[derive(Clone, Debug, Default)]
+pub struct ReleaseStatus {
+    pub release: String,
+    pub resources: Vec<Readiness>,
+}
+
+call_error! {
+    pub enum StatusError("status") {
+        response: Option<String>,
+    }
+}
+
+pub async fn status(req: Status) -> Result<ReleaseStatus, StatusError> {
+    let res = HelmCallImpl::status(req.into()).await;
+    if let Some(err) = res.0.err.first() {
+        return Err(StatusError::new(
+            res.0.err_kind.as_str().into(),
+            err.clone(),
+            res.0.logs,
+            match res.0.data.as_str() {
+                "" => None,
+                d => Some(d.to_string()),
+            },
+        ));
+    }
+
+    Ok(ReleaseStatus {
+        release: res.0.data,
+        resources: res
+            .0
+            .resources
+            .into_iter()
+            .map(|r| Readiness {
+                kind: r.kind,
+                name: r.name,
+                namespace: r.namespace,
+                ready: r.ready,
+                reason: r.reason,
+            })
+            .collect(),
+    })
+}
@@ -0,0 +1,79 @@
+use crate::{
+    HelmCall as _, HelmCallImpl, TemplateRequest, chart_source::ChartSource, env::Env,
+    error::call_error,
+};
+
+#[derive(Clone, Debug, Default)]
+pub struct Template {
+    pub release_name: String,
+    pub chart: String,
+    pub version: String,
+    pub ns: String,
+    pub values: Vec<u8>,
+    pub kube_version: Option<String>,
+    pub api_versions: Vec<String>,
+    pub include_crds: bool,
+    pub is_upgrade: bool,
+    pub env: Env,
+    // DependencyUpdate updates the dependencies missing from the charts
+    // directory before rendering, like `helm dependency update`
+    pub dependency_update: bool,
+    pub chart_source: ChartSource,
+}
+
+impl From<Template> for TemplateRequest {
+    fn from(req: Template) -> Self {
+        TemplateRequest {
+            release_name: req.release_name,
+            chart: req.chart,
+            version: req.version,
+            ns: req.ns,
+            values: req.values,
+            kube_version: req.kube_version.into_iter().collect(),
+            api_versions: req.api_versions,
+            include_crds: req.include_crds,
+            is_upgrade: req.is_upgrade,
+            env: req.env.into(),
+            dependency_update: req.dependency_update,
+            cert_file: req.chart_source.cert_file,
+            key_file: req.chart_source.key_file,
+            ca_file: req.chart_source.ca_file,
+            insecure: req.chart_source.insecure,
+            plain_http: req.chart_source.plain_http,
+            username: req.chart_source.username,
+            password: req.chart_source.password,
+            pass_credentials_all: req.chart_source.pass_credentials_all,
+            repo_url: req.chart_source.repo_url,
+            keyring: req.chart_source.keyring,
+            verify: req.chart_source.verify,
+        }
+    }
+}
+
+#[derive(Clone, Debug, Default)]
+pub struct Rendered {
+    pub manifest: String,
+    pub notes: String,
+    pub hooks: String,
+}
+
+call_error! {
+    pub enum TemplateError("template") {}
+}
+
+pub async fn template(req: Template) -> Result<Rendered, TemplateError> {
+    let res = HelmCallImpl::template(req.into()).await;
+    if let Some(err) = res.0.err.first() {
+        return Err(TemplateError::new(
+            res.0.err_kind.as_str().into(),
+            err.clone(),
+            res.0.logs,
+        ));
+    }
+
+    Ok(Rendered {
+        manifest: res.0.manifest,
+        notes: res.0.notes,
+        hooks: res.0.hooks,
+    })
+}
@@ -1,6 +1,11 @@
-use thiserror::Error;
-
-use crate::{HelmCall as _, HelmCallImpl, UninstallRequest, env::Env};
+use crate::{
+    HelmCall as _, HelmCallImpl, UninstallRequest,
+    cancel::CancelGuard,
+    env::Env,
+    error::{ErrorKind, call_error},
+    events::{EventSender, with_events},
+    release::{Release, release},
+};
 
 #[derive(Clone, Debug)]
 pub struct Uninstall {
@@ -15,6 +20,11 @@ pub struct Uninstall {
     pub timeout: Vec<i64>,
     pub description: String,
     pub env: Env,
+    // Events receives the progress of the operation while it runs
+    pub events: Option<EventSender>,
+    // LockTimeout is how long in seconds to wait for another call of this
+    // process on the release, Some(0) fails fast and None waits for it
+    pub lock_timeout: Option<i64>,
 }
 
 impl Default for Uninstall {
@@ -31,6 +41,8 @@ impl Default for Uninstall {
             timeout: vec![300],
             description: Default::default(),
             env: Default::default(),
+            events: Default::default(),
+            lock_timeout: Default::default(),
         }
     }
 }
@@ -49,30 +61,47 @@ impl From<Uninstall> for UninstallRequest {
             timeout: req.timeout,
             description: req.description,
             env: req.env.into(),
+            id: 0,
+            events: req.events.is_some(),
+            lock_timeout: req.lock_timeout.into_iter().collect(),
         }
     }
 }
 
-#[derive(Error, Debug)]
-pub enum UninstallError {
-    #[error("uninstall error: {err}")]
-    Uninstall {
+call_error! {
+    pub enum UninstallError("uninstall") {
         response: Option<String>,
-        err: String,
-    },
+    }
 }
 
-pub async fn uninstall(req: Uninstall) -> Result<String, UninstallError> {
-    let res = HelmCallImpl::uninstall(req.into()).await;
+pub async fn uninstall(req: Uninstall) -> Result<Release, UninstallError> {
+    let guard = CancelGuard::new();
+    let events = req.events.clone();
+    let res = with_events(
+        guard.id(),
+        events,
+        HelmCallImpl::uninstall(UninstallRequest {
+            id: guard.id(),
+            ..req.into()
+        }),
+    )
+    .await;
+    guard.disarm();
     if let Some(err) = res.0.err.first() {
-        return Err(UninstallError::Uninstall {
-            response: match res.0.data.as_str() {
+        let kind = match res.0.cancelled {
+            true => ErrorKind::Cancelled,
+            false => res.0.err_kind.as_str().into(),
+        };
+        return Err(UninstallError::new(
+            kind,
+            err.clone(),
+            res.0.logs,
+            match res.0.data.as_str() {
                 "" => None,
                 d => Some(d.to_string()),
             },
-            err: err.clone(),
-        });
+        ));
     }
 
-    Ok(res.0.data)
+    Ok(release(res.0.release, res.0.data, res.0.lock_wait))
 }
@@ -1,6 +1,13 @@
-use thiserror::Error;
-
-use crate::{HelmCall as _, HelmCallImpl, UpgradeRequest, env::Env};
+use crate::{
+    HelmCall as _, HelmCallImpl, UpgradeRequest,
+    cancel::CancelGuard,
+    chart_source::ChartSource,
+    env::Env,
+    error::{ErrorKind, call_error},
+    events::{EventSender, with_events},
+    recover_release::RecoverPolicy,
+    release::{Release, release},
+};
 
 #[derive(Clone, Debug)]
 pub struct Upgrade {
@@ -14,7 +21,33 @@ pub struct Upgrade {
     pub reuse_values: bool,
     pub reset_values: bool,
     pub values: Vec<u8>,
+    pub values_files: Vec<String>,
+    pub set_values: Vec<String>,
+    pub set_string_values: Vec<String>,
+    pub set_json_values: Vec<String>,
+    pub set_file_values: Vec<String>,
+    pub set_literal_values: Vec<String>,
+    pub install: bool,
+    pub create_namespace: bool,
+    pub atomic: bool,
+    pub cleanup_on_fail: bool,
+    pub wait_for_jobs: bool,
+    pub max_history: i64,
     pub env: Env,
+    // DependencyUpdate updates the dependencies missing from charts/ first
+    pub dependency_update: bool,
+    // ChartSource configures the repository or OCI registry of the chart
+    pub chart_source: ChartSource,
+    // Events receives the progress of the operation while it runs
+    pub events: Option<EventSender>,
+    // LockTimeout is how long in seconds to wait for another call of this
+    // process on the release, Some(0) fails fast and None waits for it
+    pub lock_timeout: Option<i64>,
+    // RecoverPending recovers the release with RecoverPolicy before upgrading,
+    // when its latest revision is pending since RecoverPendingAfter seconds
+    pub recover_pending: bool,
+    pub recover_policy: RecoverPolicy,
+    pub recover_pending_after: i64,
 }
 
 impl Default for Upgrade {
@@ -30,7 +63,26 @@ impl Default for Upgrade {
             reuse_values: Default::default(),
             reset_values: Default::default(),
             values: Default::default(),
+            values_files: Default::default(),
+            set_values: Default::default(),
+            set_string_values: Default::default(),
+            set_json_values: Default::default(),
+            set_file_values: Default::default(),
+            set_literal_values: Default::default(),
+            install: Default::default(),
+            create_namespace: Default::default(),
+            atomic: Default::default(),
+            cleanup_on_fail: Default::default(),
+            wait_for_jobs: Default::default(),
+            max_history: Default::default(),
             env: Default::default(),
+            dependency_update: Default::default(),
+            chart_source: Default::default(),
+            events: Default::default(),
+            lock_timeout: Default::default(),
+            recover_pending: Default::default(),
+            recover_policy: Default::default(),
+            recover_pending_after: Default::default(),
         }
     }
 }
@@ -48,31 +100,114 @@ impl From<Upgrade> for UpgradeRequest {
             reuse_values: req.reuse_values,
             reset_values: req.reset_values,
             values: req.values,
+            values_files: req.values_files,
+            set_values: req.set_values,
+            set_string_values: req.set_string_values,
+            set_json_values: req.set_json_values,
+            set_file_values: req.set_file_values,
+            set_literal_values: req.set_literal_values,
+            install: req.install,
+            create_namespace: req.create_namespace,
+            atomic: req.atomic,
+            cleanup_on_fail: req.cleanup_on_fail,
+            wait_for_jobs: req.wait_for_jobs,
+            max_history: req.max_history,
             env: req.env.into(),
+            id: 0,
+            events: req.events.is_some(),
+            lock_timeout: req.lock_timeout.into_iter().collect(),
+            recover_pending: req.recover_pending,
+            recover_policy: req.recover_policy.as_str().to_string(),
+            recover_pending_after: req.recover_pending_after,
+            dependency_update: req.dependency_update,
+            cert_file: req.chart_source.cert_file,
+            key_file: req.chart_source.key_file,
+            ca_file: req.chart_source.ca_file,
+            insecure: req.chart_source.insecure,
+            plain_http: req.chart_source.plain_http,
+            username: req.chart_source.username,
+            password: req.chart_source.password,
+            pass_credentials_all: req.chart_source.pass_credentials_all,
+            repo_url: req.chart_source.repo_url,
+            keyring: req.chart_source.keyring,
+            verify: req.chart_source.verify,
         }
     }
 }
 
-#[derive(Error, Debug)]
-pub enum UpgradeError {
-    #[error("upgrade error: {err}")]
-    Upgrade {
+call_error! {
+    pub enum UpgradeError("upgrade") {
         response: Option<String>,
+        // Revision the release ended up on, None if it does not exist
+        revision: Option<i64>,
+    }
+    // RolledBack and Uninstalled are returned instead of the kind variant
+    // when the failure was handled by the atomic option
+    #[error("upgrade failed and the release was rolled back to revision {revision}: {err}")]
+    RolledBack {
+        revision: i64,
+        err: String,
+        kind: ErrorKind,
+        logs: Vec<String>,
+    }
+    #[error("upgrade failed and the release was uninstalled: {err}")]
+    Uninstalled {
         err: String,
-    },
+        revision: Option<i64>,
+        kind: ErrorKind,
+        logs: Vec<String>,
+    }
 }
 
-pub async fn upgrade(req: Upgrade) -> Result<String, UpgradeError> {
-    let res = HelmCallImpl::upgrade(req.into()).await;
+pub async fn upgrade(req: Upgrade) -> Result<Release, UpgradeError> {
+    let guard = CancelGuard::new();
+    let events = req.events.clone();
+    let res = with_events(
+        guard.id(),
+        events,
+        HelmCallImpl::upgrade(UpgradeRequest {
+            id: guard.id(),
+            ..req.into()
+        }),
+    )
+    .await;
+    guard.disarm();
     if let Some(err) = res.0.err.first() {
-        return Err(UpgradeError::Upgrade {
-            response: match res.0.data.as_str() {
+        let kind = match res.0.cancelled {
+            true => ErrorKind::Cancelled,
+            false => res.0.err_kind.as_str().into(),
+        };
+        let revision = match res.0.revision {
+            0 => None,
+            r => Some(r),
+        };
+        if res.0.rolled_back {
+            return Err(UpgradeError::RolledBack {
+                revision: res.0.revision,
+                err: err.clone(),
+                kind,
+                logs: res.0.logs,
+            });
+        }
+        if res.0.uninstalled {
+            return Err(UpgradeError::Uninstalled {
+                err: err.clone(),
+                revision,
+                kind,
+                logs: res.0.logs,
+            });
+        }
+        return Err(UpgradeError::new(
+            kind,
+            err.clone(),
+            res.0.logs,
+            match res.0.data.as_str() {
                 "" => None,
                 d => Some(d.to_string()),
             },
-            err: err.clone(),
-        });
+            revision,
+        ));
     }
 
-    Ok(res.0.data)
+    Ok(release(res.0.release, res.0.data, res.0.lock_wait))
 }